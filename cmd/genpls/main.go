@@ -8,37 +8,45 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
-	"strings"
 
 	"github.com/WinPooh32/genpls"
 	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/generators/accessors"
+	"github.com/WinPooh32/genpls/generators/breaker"
+	"github.com/WinPooh32/genpls/generators/builder"
+	"github.com/WinPooh32/genpls/generators/cache"
+	"github.com/WinPooh32/genpls/generators/fake"
+	"github.com/WinPooh32/genpls/generators/metrics"
 	"github.com/WinPooh32/genpls/generators/mock"
+	"github.com/WinPooh32/genpls/generators/options"
 	"github.com/WinPooh32/genpls/generators/proxy"
+	"github.com/WinPooh32/genpls/generators/recovery"
+	"github.com/WinPooh32/genpls/generators/retry"
 	"github.com/WinPooh32/genpls/generators/stub"
+	"github.com/WinPooh32/genpls/generators/trace"
 )
 
 // Enabled generators.
 var generators = map[gen.GeneratorName]gen.Func{
-	"stub":  stub.Generate,
-	"proxy": proxy.Generate,
-	"mock":  mock.Generate,
-}
-
-type argSet []string
-
-func (a *argSet) String() string {
-	return strings.Join(*a, ", ")
-}
-
-func (a *argSet) Set(s string) error {
-	*a = strings.Split(s, ",")
-	return nil
+	"stub":      stub.Generate,
+	"proxy":     proxy.Generate,
+	"mock":      mock.Generate,
+	"fake":      fake.Generate,
+	"recover":   recovery.Generate,
+	"retry":     retry.Generate,
+	"breaker":   breaker.Generate,
+	"metrics":   metrics.Generate,
+	"trace":     trace.Generate,
+	"cache":     cache.Generate,
+	"builder":   builder.Generate,
+	"options":   options.Generate,
+	"accessors": accessors.Generate,
 }
 
 type flags struct {
 	jobs     int
 	dir      string
-	patterns argSet
+	patterns gen.ArgSet
 }
 
 func main() {
@@ -0,0 +1,450 @@
+package analysis_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/gen/analysis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+const src = `package p
+
+import (
+	"context"
+	"io"
+)
+
+type Base interface {
+	// Close doc
+	Close() error
+}
+
+type Service interface {
+	Base
+	io.Reader
+
+	// Get doc
+	//
+	// details
+	//genpls:redact id
+	Get(ctx context.Context, id string) (v int, err error)
+	Log(string, ...any)
+}
+
+type Alias = Service
+
+type Generic[T any, U comparable] interface {
+	Put(T) U
+}
+
+type Embedding[V any] interface {
+	Generic[[]V, string]
+	context.Context
+}
+
+type Instance = Generic[int, string]
+
+type Number interface {
+	~int | ~float64
+}
+
+type Keyer interface {
+	comparable
+	Key() string
+}
+
+type Calc[T Number, K Keyer] interface {
+	Sum(...T) T
+	Get(K) T
+}
+
+type Results interface {
+	A(ctx context.Context, r0 int) (int, error)
+	B() (r0 int, _ error)
+}
+
+type Placeholders interface {
+	M(a1 int, _ string, _ bool) (a2 error)
+}
+
+type S struct {
+	// A doc
+	A, b int ` + "`json:\"a\"`" + `
+	Base
+}
+`
+
+// please returns the command for the type spec declared at the source.
+func please(t *testing.T, name string) gen.Please {
+	t.Helper()
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+
+	pkg, err := conf.Check("p", fset, []*ast.File{file}, nil)
+	require.NoError(t, err)
+
+	var spec *ast.TypeSpec
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		if ts, ok := node.(*ast.TypeSpec); ok && ts.Name.Name == name {
+			spec = ts
+		}
+
+		return spec == nil
+	})
+	require.NotNil(t, spec)
+
+	return gen.Please{
+		Filename: "p.go",
+		TS: &gen.TypeSpec{
+			Pkg: &packages.Package{
+				Name:   "p",
+				Fset:   fset,
+				Syntax: []*ast.File{file},
+				Types:  pkg,
+			},
+			Spec: spec,
+		},
+	}
+}
+
+func TestInterfaceOf(t *testing.T) {
+	t.Parallel()
+
+	iface, err := analysis.InterfaceOf(please(t, "Service"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Service", iface.Name)
+
+	methods := map[string]analysis.Method{}
+	for _, meth := range iface.Methods {
+		methods[meth.Name] = meth
+	}
+
+	require.Len(t, methods, 4)
+
+	assert.Equal(t, "Base", methods["Close"].Origin.Obj().Name())
+	assert.Equal(t, "Close doc\n", methods["Close"].Doc.Text())
+	assert.Equal(t, "Reader", methods["Read"].Origin.Obj().Name())
+	assert.Equal(t, "Service", methods["Get"].Origin.Obj().Name())
+
+	get := methods["Get"]
+	assert.Equal(t, "Get doc\n\ndetails\n", get.DocText())
+	assert.Equal(t, "// Get doc\n//\n// details\n", get.DocComment())
+	assert.Empty(t, methods["Log"].DocComment())
+	assert.Equal(t, "ctx", get.Ctx())
+	assert.True(t, get.Err())
+	assert.Equal(t, "ctx, id", get.Args())
+	assert.Equal(t, []string{"v", "err"}, get.ResultNames())
+	assert.Equal(t, "(ctx context.Context, id string) (v int, err error)", get.Sig(nil))
+
+	log := methods["Log"]
+	assert.True(t, log.Variadic)
+	assert.Empty(t, log.Ctx())
+	assert.False(t, log.Err())
+	assert.Equal(t, "a0, a1...", log.Args())
+	assert.Equal(t, "(a0 string, a1 ...any)", log.Sig(nil))
+
+	read := methods["Read"]
+	assert.Equal(t, []string{"n", "err"}, read.ResultNames())
+	assert.Equal(t, "(p []byte) (n int, err error)", read.Sig(nil))
+	assert.Equal(t, "(p []byte) (n int, err error)", read.NamedSig(nil))
+}
+
+func TestInterfaceOf_order(t *testing.T) {
+	t.Parallel()
+
+	iface, err := analysis.InterfaceOf(please(t, "Service"))
+	require.NoError(t, err)
+
+	names := func() []string {
+		var names []string
+		for _, meth := range iface.Methods {
+			names = append(names, meth.Name)
+		}
+
+		return names
+	}
+
+	// Methods of the embedded interfaces are placed at the embedding position.
+	assert.Equal(t, []string{"Close", "Read", "Get", "Log"}, names())
+
+	iface.SortMethods()
+	assert.Equal(t, []string{"Close", "Get", "Log", "Read"}, names())
+
+	iface, err = analysis.InterfaceOf(please(t, "Embedding"))
+	require.NoError(t, err)
+
+	// The context.Context declaration is not loaded, its methods are ordered by their positions.
+	assert.Equal(t, []string{"Put", "Deadline", "Done", "Err", "Value"}, names())
+}
+
+func TestInterfaceOf_alias(t *testing.T) {
+	t.Parallel()
+
+	iface, err := analysis.InterfaceOf(please(t, "Alias"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Alias", iface.Name)
+	assert.Equal(t, "Service", iface.Named.Obj().Name())
+	assert.Len(t, iface.Methods, 4)
+}
+
+func TestInterfaceOf_generic(t *testing.T) {
+	t.Parallel()
+
+	iface, err := analysis.InterfaceOf(please(t, "Generic"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "[T any, U comparable]", analysis.TypeParamsDecl(iface.TypeParams, nil))
+	assert.Equal(t, "[T, U]", analysis.TypeArgs(iface.TypeParams))
+
+	require.Len(t, iface.Methods, 1)
+	assert.Equal(t, "(a0 T) U", iface.Methods[0].Sig(nil))
+	assert.Equal(t, "(a0 T) (r0 U)", iface.Methods[0].NamedSig(nil))
+}
+
+func TestInterfaceOf_embedded(t *testing.T) {
+	t.Parallel()
+
+	iface, err := analysis.InterfaceOf(please(t, "Embedding"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "[V any]", analysis.TypeParamsDecl(iface.TypeParams, nil))
+
+	methods := map[string]analysis.Method{}
+	for _, meth := range iface.Methods {
+		methods[meth.Name] = meth
+	}
+
+	require.Len(t, methods, 5)
+
+	put := methods["Put"]
+	assert.Equal(t, "p.Generic[[]V, string]", put.Origin.String())
+	assert.Equal(t, "(a0 []V) string", put.Sig(nil))
+
+	// The doc of the method embedded from the other package is parsed from its source file.
+	deadline := methods["Deadline"]
+	assert.Equal(t, "Context", deadline.Origin.Obj().Name())
+	assert.Contains(t, deadline.DocText(), "Deadline returns")
+}
+
+func TestInterfaceOf_instance(t *testing.T) {
+	t.Parallel()
+
+	iface, err := analysis.InterfaceOf(please(t, "Instance"))
+	require.NoError(t, err)
+
+	assert.Empty(t, iface.TypeParams)
+	assert.Nil(t, iface.TypeParamList())
+
+	require.Len(t, iface.Methods, 1)
+	assert.Equal(t, "(a0 int) string", iface.Methods[0].Sig(nil))
+}
+
+func TestInterfaceOf_notInterface(t *testing.T) {
+	t.Parallel()
+
+	_, err := analysis.InterfaceOf(please(t, "S"))
+	assert.ErrorContains(t, err, `type "S" must be an interface`)
+}
+
+func TestInterfaceOf_constraint(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"Number", "Keyer"} {
+		_, err := analysis.InterfaceOf(please(t, name))
+		assert.ErrorContains(t, err, `p.go:`)
+		assert.ErrorContains(t, err, `type "`+name+`" is a constraint interface and can't be implemented`)
+	}
+}
+
+func TestInterfaceOf_constrained(t *testing.T) {
+	t.Parallel()
+
+	iface, err := analysis.InterfaceOf(please(t, "Calc"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "[T Number, K Keyer]",
+		analysis.TypeParamsDecl(iface.TypeParams, types.RelativeTo(iface.Named.Obj().Pkg())))
+	require.Len(t, iface.Methods, 2)
+	assert.Equal(t, "(a0 ...T) T", iface.Methods[0].Sig(nil))
+	assert.Equal(t, "(a0 K) T", iface.Methods[1].Sig(nil))
+}
+
+func TestStructOf(t *testing.T) {
+	t.Parallel()
+
+	strct, err := analysis.StructOf(please(t, "S"))
+	require.NoError(t, err)
+
+	require.Len(t, strct.Fields, 3)
+
+	a := strct.Fields[0]
+	assert.Equal(t, "A", a.Name)
+	assert.True(t, a.Exported)
+	assert.Equal(t, "a", a.Tag.Get("json"))
+	assert.Equal(t, "A doc\n", a.Doc.Text())
+
+	b := strct.Fields[1]
+	assert.Equal(t, "b", b.Name)
+	assert.False(t, b.Exported)
+	assert.Equal(t, "A doc\n", b.Doc.Text())
+
+	base := strct.Fields[2]
+	assert.True(t, base.Embedded)
+	assert.Nil(t, base.Doc)
+}
+
+func TestInterfacesOf(t *testing.T) {
+	t.Parallel()
+
+	pls := please(t, "Service")
+
+	imports := gen.NewImports(pls.TS.Pkg.Types, nil)
+
+	ifaces, err := analysis.InterfacesOf([]gen.Please{pls}, imports)
+	require.NoError(t, err)
+	require.Len(t, ifaces, 1)
+
+	// The parameter named id is declared by the generated methods.
+	assert.Equal(t, gen.PkgName("id_1"), imports.Add("example.com/id"))
+	assert.Equal(t, gen.PkgName("errors"), imports.Add("errors"))
+}
+
+func TestInterfacesOf_locals(t *testing.T) {
+	t.Parallel()
+
+	pls := please(t, "Service")
+
+	ifaces, err := analysis.InterfacesOf([]gen.Please{pls}, gen.NewImports(pls.TS.Pkg.Types, nil), "id", "id_1", "v")
+	require.NoError(t, err)
+	require.Len(t, ifaces, 1)
+
+	for _, meth := range ifaces[0].Methods {
+		if meth.Name != "Get" {
+			continue
+		}
+
+		assert.Equal(t, "(ctx context.Context, id_2 string) (v_1 int, err error)", meth.Sig(nil))
+		assert.Equal(t, []string{"ctx", "id"}, meth.DeclaredArgNames())
+		assert.Equal(t, "func(ctx context.Context, id_2 string) (v_1 int, err error)", meth.Signature.String())
+	}
+}
+
+func TestMethod_ResultNames(t *testing.T) {
+	t.Parallel()
+
+	pls := please(t, "Results")
+
+	ifaces, err := analysis.InterfacesOf([]gen.Please{pls}, gen.NewImports(pls.TS.Pkg.Types, nil), "r1")
+	require.NoError(t, err)
+	require.Len(t, ifaces, 1)
+
+	meth := ifaces[0].Methods[0]
+
+	// Generated names don't clash with the parameter r0 and the local r1.
+	assert.Equal(t, []string{"r0_1", "r1_1"}, meth.ResultNames())
+	assert.Equal(t, "(ctx context.Context, r0 int) (r0_1 int, r1_1 error)", meth.NamedSig(nil))
+
+	meth = ifaces[0].Methods[1]
+
+	// Generated names don't clash with the declared result r0.
+	assert.Equal(t, []string{"r0_1", "r1_1"}, meth.IndexedResultNames())
+	assert.Equal(t, "() (r0_1 int, r1_1 error)", meth.NamedSig(nil))
+}
+
+func TestInterfaceOf_placeholders(t *testing.T) {
+	t.Parallel()
+
+	iface, err := analysis.InterfaceOf(please(t, "Placeholders"))
+	require.NoError(t, err)
+	require.Len(t, iface.Methods, 1)
+
+	// Placeholders don't clash with the names declared by the signature.
+	assert.Equal(t, []string{"a1", "a1_1", "a2_1"}, iface.Methods[0].ArgNames())
+}
+
+func TestInterface_CheckMethods(t *testing.T) {
+	t.Parallel()
+
+	iface, err := analysis.InterfaceOf(please(t, "Service"))
+	require.NoError(t, err)
+
+	require.NoError(t, iface.CheckMethods("Close", "Get", "Read"))
+	require.ErrorContains(t, iface.CheckMethods("Get", "Put"), `unknown method "Put"`)
+}
+
+func TestInterface_CheckNotMethods(t *testing.T) {
+	t.Parallel()
+
+	iface, err := analysis.InterfaceOf(please(t, "Service"))
+	require.NoError(t, err)
+
+	require.NoError(t, iface.CheckNotMethods("InvalidateGet", "GetReturns"))
+	require.ErrorContains(t, iface.CheckNotMethods("InvalidateGet", "Read"),
+		"generated Read clashes with the method of the interface")
+}
+
+func TestInstance(t *testing.T) {
+	t.Parallel()
+
+	pls := please(t, "Generic")
+
+	named, ok := pls.TS.Pkg.Types.Scope().Lookup("Generic").Type().(*types.Named)
+	require.True(t, ok)
+
+	assert.Equal(t, "p.Generic[T, U]", analysis.Instance(named, named.TypeParams()).String())
+	assert.Same(t, named, analysis.Instance(named, nil))
+}
+
+func TestUpperFirst(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Next", analysis.UpperFirst("next"))
+	assert.Equal(t, "Ünit", analysis.UpperFirst("ünit"))
+	assert.Empty(t, analysis.UpperFirst(""))
+}
+
+func TestConcreteName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ProxyService", analysis.ConcreteName("Proxy", "Service"))
+	assert.Equal(t, "ProxyService", analysis.ConcreteName("Proxy", "service"))
+}
+
+func TestDecoratedMethodOf(t *testing.T) {
+	t.Parallel()
+
+	pls := please(t, "Results")
+
+	ifaces, err := analysis.InterfacesOf([]gen.Please{pls}, gen.NewImports(pls.TS.Pkg.Types, nil), "r1")
+	require.NoError(t, err)
+	require.Len(t, ifaces, 1)
+
+	decorator := analysis.DecoratorOf("Retry", ifaces[0], nil)
+	assert.Equal(t, analysis.Decorator{ConcrName: "RetryResults", InterfaceName: "Results"}, decorator)
+
+	assert.Equal(t, analysis.DecoratedMethod{
+		Name:    "A",
+		Sig:     "(ctx context.Context, r0 int) (r0_1 int, r1_1 error)",
+		Args:    "ctx, r0",
+		Results: "r0_1, r1_1",
+		Ret:     true,
+		Err:     "r1_1",
+		Ctx:     "ctx",
+	}, analysis.DecoratedMethodOf(ifaces[0].Methods[0], nil))
+}
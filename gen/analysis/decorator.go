@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"go/types"
+	"strings"
+)
+
+// Decorator is the model of the generated type wrapping the interface like the retrying or the tracing wrappers.
+type Decorator struct {
+	// ConcrName is the name of the generated type.
+	ConcrName      string
+	InterfaceName  string
+	TypeParamsDecl string
+	TypeParams     string
+}
+
+// DecoratorOf returns the model of the wrapper of the interface named by the prefix and the interface name.
+func DecoratorOf(prefix string, iface Interface, qf types.Qualifier) Decorator {
+	return Decorator{
+		ConcrName:      ConcreteName(prefix, iface.Name),
+		InterfaceName:  iface.Name,
+		TypeParamsDecl: TypeParamsDecl(iface.TypeParams, qf),
+		TypeParams:     TypeArgs(iface.TypeParams),
+	}
+}
+
+// DecoratedMethod is the model of the wrapper's method calling the wrapped method.
+type DecoratedMethod struct {
+	Name string
+	// Doc is the method doc as the comment lines.
+	Doc string
+	// Sig is the signature with named results.
+	Sig string
+	// Args are the arguments passing the parameters through.
+	Args string
+	// Results are the results names joined by commas.
+	Results string
+	Ret     bool
+	// Err is the name of the trailing error result.
+	Err string
+	// Ctx is the name of the leading context parameter.
+	Ctx string
+}
+
+// DecoratedMethodOf returns the model of the wrapper's method calling the method.
+func DecoratedMethodOf(meth Method, qf types.Qualifier) DecoratedMethod {
+	return DecoratedMethod{
+		Name:    meth.Name,
+		Doc:     meth.DocComment(),
+		Sig:     meth.NamedSig(qf),
+		Args:    meth.Args(),
+		Results: strings.Join(meth.ResultNames(), ", "),
+		Ret:     len(meth.Results) > 0,
+		Err:     meth.ErrName(),
+		Ctx:     meth.Ctx(),
+	}
+}
+
+// DecoratedMethodsOf returns the models of the wrapper's methods calling the interface methods.
+func DecoratedMethodsOf(iface Interface, qf types.Qualifier) []DecoratedMethod {
+	meths := make([]DecoratedMethod, 0, len(iface.Methods))
+
+	for _, meth := range iface.Methods {
+		meths = append(meths, DecoratedMethodOf(meth, qf))
+	}
+
+	return meths
+}
@@ -0,0 +1,831 @@
+package analysis
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+// Interface is the model of the interface type targeted by a directive.
+type Interface struct {
+	// Name is the name of the directive's type spec.
+	// It differs from the Named's name if the type spec is an alias.
+	Name string
+	// Pos is the position of the directive's type spec.
+	Pos token.Position
+	// Named is the named interface type. Aliases are resolved.
+	Named *types.Named
+	// Type is the underlying interface type.
+	Type *types.Interface
+	// TypeParams are the type parameters of the interface.
+	TypeParams []TypeParam
+	// Methods are the methods of the interface's method set including the embedded ones.
+	// They are in the declaration order, methods of the embedded interfaces are placed at the embedding position.
+	Methods []Method
+}
+
+// TypeParam is a type parameter of the generic type.
+type TypeParam struct {
+	Name       string
+	Constraint types.Type
+}
+
+// Method is a method of the interface.
+type Method struct {
+	Name string
+	// Func is the method object.
+	Func *types.Func
+	// Signature is the method signature.
+	Signature *types.Signature
+	// Params are the parameters, unnamed and blank ones are named as a0, a1, ...
+	// The type of the variadic parameter is a slice.
+	Params []Var
+	// Results are the results as they are declared, names may be empty.
+	Results  []Var
+	Variadic bool
+	// Origin is the named interface declaring the method.
+	// It is the analyzed interface itself or one of the embedded interfaces.
+	Origin *types.Named
+	// Doc is the method doc comment.
+	// Docs of the methods embedded from other packages are parsed from their source files,
+	// it is nil if the source file is not available.
+	Doc *ast.CommentGroup
+	// locals are the identifiers declared by the generated methods, see [InterfacesOf].
+	locals []string
+}
+
+// Var is a parameter or a result of the method.
+type Var struct {
+	Name string
+	Type types.Type
+}
+
+// InterfaceOf returns the model of the directive's interface.
+func InterfaceOf(pls gen.Please) (Interface, error) {
+	name := pls.TS.Spec.Name.Name
+	position := pls.TS.Pkg.Fset.Position(pls.TS.Spec.Pos())
+
+	object := pls.TS.Pkg.Types.Scope().Lookup(name)
+	if object == nil {
+		return Interface{}, fmt.Errorf("%s: object %s not found", position, name)
+	}
+
+	if _, ok := object.(*types.TypeName); !ok {
+		return Interface{}, fmt.Errorf("%s: %v is not a named type", position, object)
+	}
+
+	named, ok := types.Unalias(object.Type()).(*types.Named)
+	if !ok {
+		return Interface{}, fmt.Errorf("%s: type %q must be an interface", position, name)
+	}
+
+	iface, ok := named.Underlying().(*types.Interface)
+	if !ok {
+		return Interface{}, fmt.Errorf("%s: type %q must be an interface", position, name)
+	}
+
+	// Type sets of the constraint interfaces are not fully described by their methods,
+	// so they can be used as the type parameters constraints only and can't be implemented.
+	if !iface.IsMethodSet() {
+		return Interface{}, fmt.Errorf("%s: type %q is a constraint interface and can't be implemented", position, name)
+	}
+
+	origins := map[string]*types.Named{}
+	methodOrigins(named, named, origins)
+
+	docs := newDocIndex(pls.TS.Pkg.Fset, pls.TS.Pkg.Syntax)
+
+	mset := types.NewMethodSet(named)
+
+	methods := make([]Method, 0, mset.Len())
+
+	for i := range mset.Len() {
+		fn, ok := mset.At(i).Obj().(*types.Func)
+		if !ok {
+			return Interface{}, fmt.Errorf("%s: unexpected object %T", position, mset.At(i).Obj())
+		}
+
+		sig, ok := fn.Type().(*types.Signature)
+		if !ok {
+			return Interface{}, fmt.Errorf("%s: unexpected type %T", position, fn.Type())
+		}
+
+		methods = append(methods, Method{
+			Name:      fn.Name(),
+			Func:      fn,
+			Signature: sig,
+			Params:    params(sig),
+			Results:   vars(sig.Results()),
+			Variadic:  sig.Variadic(),
+			Origin:    origins[fn.Name()],
+			Doc:       docs.doc(fn),
+		})
+	}
+
+	order := methodOrder(named, interfaceSpecs(pls.TS.Pkg.Syntax))
+
+	slices.SortStableFunc(methods, func(a, b Method) int {
+		return cmp.Compare(order.index(a.Name), order.index(b.Name))
+	})
+
+	return Interface{
+		Name:       name,
+		Pos:        position,
+		Named:      named,
+		Type:       iface,
+		TypeParams: typeParams(declaredTypeParams(named)),
+		Methods:    methods,
+	}, nil
+}
+
+// order is the methods names in the declaration order.
+type order []string
+
+// index returns the index of the method, unknown methods are placed at the end.
+func (o order) index(name string) int {
+	if i := slices.Index(o, name); i >= 0 {
+		return i
+	}
+
+	return len(o)
+}
+
+// methodOrder returns the methods names in the declaration order.
+// The order of the interfaces which declarations are not loaded is the order of the methods positions.
+func methodOrder(typ types.Type, specs map[token.Pos]*ast.InterfaceType) order {
+	var names order
+
+	var walk func(typ types.Type)
+
+	walk = func(typ types.Type) {
+		iface, ok := typ.Underlying().(*types.Interface)
+		if !ok {
+			return
+		}
+
+		var spec *ast.InterfaceType
+
+		if named, ok := types.Unalias(typ).(*types.Named); ok {
+			spec = specs[named.Obj().Pos()]
+		}
+
+		if spec == nil {
+			explicit := make([]*types.Func, iface.NumExplicitMethods())
+			for i := range explicit {
+				explicit[i] = iface.ExplicitMethod(i)
+			}
+
+			slices.SortFunc(explicit, func(a, b *types.Func) int {
+				return cmp.Compare(a.Pos(), b.Pos())
+			})
+
+			for _, fn := range explicit {
+				names = append(names, fn.Name())
+			}
+
+			for i := range iface.NumEmbeddeds() {
+				walk(iface.EmbeddedType(i))
+			}
+
+			return
+		}
+
+		// The embedded types are in the declaration order unlike the explicit methods.
+		embedded := 0
+
+		for _, field := range spec.Methods.List {
+			if len(field.Names) > 0 {
+				names = append(names, field.Names[0].Name)
+			} else if embedded < iface.NumEmbeddeds() {
+				walk(iface.EmbeddedType(embedded))
+				embedded++
+			}
+		}
+	}
+
+	walk(typ)
+
+	return names
+}
+
+// interfaceSpecs returns the interfaces declared at the files by the positions of the type specs names.
+func interfaceSpecs(files []*ast.File) map[token.Pos]*ast.InterfaceType {
+	specs := map[token.Pos]*ast.InterfaceType{}
+
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			if ts, ok := node.(*ast.TypeSpec); ok {
+				if iface, ok := ts.Type.(*ast.InterfaceType); ok {
+					specs[ts.Name.Pos()] = iface
+				}
+			}
+
+			return true
+		})
+	}
+
+	return specs
+}
+
+// methodOrigins collects the named interfaces declaring the methods.
+func methodOrigins(typ types.Type, origin *types.Named, origins map[string]*types.Named) {
+	iface, ok := typ.Underlying().(*types.Interface)
+	if !ok {
+		return
+	}
+
+	for i := range iface.NumExplicitMethods() {
+		if name := iface.ExplicitMethod(i).Name(); origins[name] == nil {
+			origins[name] = origin
+		}
+	}
+
+	for i := range iface.NumEmbeddeds() {
+		embedded := types.Unalias(iface.EmbeddedType(i))
+
+		if named, ok := embedded.(*types.Named); ok {
+			methodOrigins(named, named, origins)
+		} else {
+			methodOrigins(embedded, origin, origins)
+		}
+	}
+}
+
+// declaredTypeParams returns the type parameters of the generic type declaration.
+// It returns nil for the instantiated type, its methods signatures are already substituted.
+func declaredTypeParams(named *types.Named) *types.TypeParamList {
+	if named.TypeArgs().Len() > 0 {
+		return nil
+	}
+
+	return named.TypeParams()
+}
+
+// docIndex looks up the docs of the interfaces methods.
+// Methods of the loaded packages are looked up at their syntax,
+// methods imported from other packages are looked up at the parsed source files.
+type docIndex struct {
+	fset  *token.FileSet
+	local map[token.Pos]*ast.CommentGroup
+	files map[string]map[methodLine]*ast.CommentGroup
+}
+
+// methodLine identifies the method at the source file.
+type methodLine struct {
+	name string
+	line int
+}
+
+func newDocIndex(fset *token.FileSet, syntax []*ast.File) *docIndex {
+	return &docIndex{
+		fset:  fset,
+		local: methodDocs(syntax),
+		files: map[string]map[methodLine]*ast.CommentGroup{},
+	}
+}
+
+// doc returns the method doc or nil if the method has no doc or its source file is not available.
+func (idx *docIndex) doc(fn *types.Func) *ast.CommentGroup {
+	if doc, ok := idx.local[fn.Pos()]; ok {
+		return doc
+	}
+
+	position := idx.fset.Position(fn.Pos())
+	if !position.IsValid() || position.Filename == "" {
+		return nil
+	}
+
+	docs, ok := idx.files[position.Filename]
+	if !ok {
+		docs = fileMethodDocs(position.Filename)
+		idx.files[position.Filename] = docs
+	}
+
+	return docs[methodLine{name: fn.Name(), line: position.Line}]
+}
+
+// fileMethodDocs parses the source file and returns docs of its interfaces methods.
+// It returns nil if the file can't be parsed.
+func fileMethodDocs(filename string) map[methodLine]*ast.CommentGroup {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil
+	}
+
+	docs := map[methodLine]*ast.CommentGroup{}
+
+	inspectMethodDocs(file, func(name *ast.Ident, doc *ast.CommentGroup) {
+		docs[methodLine{name: name.Name, line: fset.Position(name.Pos()).Line}] = doc
+	})
+
+	return docs
+}
+
+// methodDocs returns docs of the interfaces methods declared at the files by the methods names positions.
+func methodDocs(files []*ast.File) map[token.Pos]*ast.CommentGroup {
+	docs := map[token.Pos]*ast.CommentGroup{}
+
+	for _, file := range files {
+		inspectMethodDocs(file, func(name *ast.Ident, doc *ast.CommentGroup) {
+			docs[name.Pos()] = doc
+		})
+	}
+
+	return docs
+}
+
+// inspectMethodDocs calls f for every documented method of the interfaces declared at the file.
+func inspectMethodDocs(file *ast.File, f func(name *ast.Ident, doc *ast.CommentGroup)) {
+	ast.Inspect(file, func(node ast.Node) bool {
+		iface, ok := node.(*ast.InterfaceType)
+		if !ok {
+			return true
+		}
+
+		for _, field := range iface.Methods.List {
+			if field.Doc != nil && len(field.Names) > 0 {
+				f(field.Names[0], field.Doc)
+			}
+		}
+
+		return true
+	})
+}
+
+// rename renames the parameters and the named results declared by the locals names.
+// The names are suffixed by _1, _2, ... like the names of the conflicting imports.
+func (m *Method) rename(locals []string) {
+	m.locals = locals
+
+	taken := map[string]bool{}
+
+	for _, v := range slices.Concat(m.Params, m.Results) {
+		taken[v.Name] = true
+	}
+
+	renamed := false
+
+	for _, vars := range [][]Var{m.Params, m.Results} {
+		for i := range vars {
+			if !slices.Contains(locals, vars[i].Name) {
+				continue
+			}
+
+			name := gen.FreeName(vars[i].Name, func(name string) bool {
+				return taken[name] || slices.Contains(locals, name)
+			})
+
+			taken[name] = true
+			vars[i].Name = name
+			renamed = true
+		}
+	}
+
+	if renamed {
+		m.Signature = types.NewSignatureType(
+			m.Signature.Recv(), nil, nil,
+			tuple(m.Signature.Params(), m.Params),
+			tuple(m.Signature.Results(), m.Results),
+			m.Variadic,
+		)
+	}
+}
+
+// tuple returns the tuple of the vars named by the names of the given vars.
+func tuple(orig *types.Tuple, vars []Var) *types.Tuple {
+	list := make([]*types.Var, orig.Len())
+
+	for i := range orig.Len() {
+		v := orig.At(i)
+		list[i] = types.NewParam(v.Pos(), v.Pkg(), vars[i].Name, v.Type())
+	}
+
+	return types.NewTuple(list...)
+}
+
+// params returns the parameters of the signature.
+// Unnamed and blank parameters are named a0, a1, ... suffixed by _1, _2, ... if the name is taken by the signature.
+func params(sig *types.Signature) []Var {
+	params := vars(sig.Params())
+
+	taken := map[string]bool{}
+
+	for _, v := range slices.Concat(params, vars(sig.Results())) {
+		taken[v.Name] = true
+	}
+
+	for i := range params {
+		if params[i].Name == "" || params[i].Name == "_" {
+			params[i].Name = gen.FreeName("a"+strconv.Itoa(i), func(name string) bool { return taken[name] })
+			taken[params[i].Name] = true
+		}
+	}
+
+	return params
+}
+
+func vars(tuple *types.Tuple) []Var {
+	vars := make([]Var, tuple.Len())
+
+	for i := range tuple.Len() {
+		vars[i] = Var{
+			Name: tuple.At(i).Name(),
+			Type: tuple.At(i).Type(),
+		}
+	}
+
+	return vars
+}
+
+func typeParams(list *types.TypeParamList) []TypeParam {
+	params := make([]TypeParam, list.Len())
+
+	for i := range list.Len() {
+		params[i] = TypeParam{
+			Name:       list.At(i).Obj().Name(),
+			Constraint: list.At(i).Constraint(),
+		}
+	}
+
+	return params
+}
+
+// TypeParamsDecl returns the type parameters declaration like [T any, U comparable].
+// It returns empty string if the type is not generic.
+func TypeParamsDecl(params []TypeParam, qf types.Qualifier) string {
+	if len(params) == 0 {
+		return ""
+	}
+
+	decls := make([]string, len(params))
+
+	for i, param := range params {
+		decls[i] = param.Name + " " + types.TypeString(param.Constraint, qf)
+	}
+
+	return "[" + strings.Join(decls, ", ") + "]"
+}
+
+// TypeArgs returns the type parameters as the type arguments like [T, U].
+// It returns empty string if the type is not generic.
+func TypeArgs(params []TypeParam) string {
+	if len(params) == 0 {
+		return ""
+	}
+
+	names := make([]string, len(params))
+
+	for i, param := range params {
+		names[i] = param.Name
+	}
+
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// Instance returns the generic type instantiated by its own type parameters like S[T, U].
+// It returns the named type as is if the type parameters are empty.
+func Instance(named *types.Named, typeParams *types.TypeParamList) types.Type {
+	if typeParams.Len() == 0 {
+		return named
+	}
+
+	args := make([]types.Type, typeParams.Len())
+
+	for i := range typeParams.Len() {
+		args[i] = typeParams.At(i)
+	}
+
+	typ, err := types.Instantiate(nil, named, args, false)
+	if err != nil {
+		// Instantiation by the own type parameters always succeeds.
+		panic(err)
+	}
+
+	return typ
+}
+
+// ConcreteName returns the name of the generated type implementing the interface like ProxyService
+// for the prefix Proxy and the interface service.
+func ConcreteName(prefix, iface string) string {
+	return prefix + UpperFirst(iface)
+}
+
+// UpperFirst returns the string with the first letter upper cased.
+func UpperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	r := []rune(s)
+
+	return strings.ToUpper(string(r[0])) + string(r[1:])
+}
+
+// ParamsDecl returns the parameters declaration like a int, b ...string.
+func (m Method) ParamsDecl(qf types.Qualifier) string {
+	decls := make([]string, len(m.Params))
+
+	for i, param := range m.Params {
+		if m.Variadic && i == len(m.Params)-1 {
+			decls[i] = param.Name + " ..." + types.TypeString(param.Type.(*types.Slice).Elem(), qf)
+		} else {
+			decls[i] = param.Name + " " + types.TypeString(param.Type, qf)
+		}
+	}
+
+	return strings.Join(decls, ", ")
+}
+
+// ArgNames returns the parameters names.
+func (m Method) ArgNames() []string {
+	names := make([]string, len(m.Params))
+
+	for i, param := range m.Params {
+		names[i] = param.Name
+	}
+
+	return names
+}
+
+// DeclaredArgNames returns the parameters names as they are declared by the interface
+// before renaming by the locals, see [InterfacesOf]. Unnamed and blank parameters are named a0, a1, ...
+func (m Method) DeclaredArgNames() []string {
+	names := m.ArgNames()
+
+	sig, ok := m.Func.Type().(*types.Signature)
+	if !ok {
+		return names
+	}
+
+	for i := range min(sig.Params().Len(), len(names)) {
+		if name := sig.Params().At(i).Name(); name != "" && name != "_" {
+			names[i] = name
+		}
+	}
+
+	return names
+}
+
+// Args returns the arguments of the method call passing the parameters through.
+func (m Method) Args() string {
+	args := strings.Join(m.ArgNames(), ", ")
+
+	if m.Variadic {
+		args += "..."
+	}
+
+	return args
+}
+
+// ResultNames returns the results names as they are declared
+// or the IndexedResultNames if any of the results is unnamed or blank.
+func (m Method) ResultNames() []string {
+	names := make([]string, len(m.Results))
+
+	for i, res := range m.Results {
+		if res.Name == "" || res.Name == "_" {
+			return m.IndexedResultNames()
+		}
+
+		names[i] = res.Name
+	}
+
+	return names
+}
+
+// IndexedResultNames returns the results names r0, r1, ... regardless of the declared names.
+// The names clashing with the parameters, the declared results names or the locals are suffixed by _1, _2, ...
+func (m Method) IndexedResultNames() []string {
+	names := make([]string, len(m.Results))
+	taken := slices.Concat(m.ArgNames(), m.locals)
+
+	// The declared results are in the scope of the method body.
+	for _, res := range m.Results {
+		taken = append(taken, res.Name)
+	}
+
+	for i := range names {
+		names[i] = gen.FreeName("r"+strconv.Itoa(i), func(name string) bool {
+			return slices.Contains(taken, name) || slices.Contains(names[:i], name)
+		})
+	}
+
+	return names
+}
+
+// ResultTypes returns the results types.
+func (m Method) ResultTypes(qf types.Qualifier) []string {
+	typs := make([]string, len(m.Results))
+
+	for i, res := range m.Results {
+		typs[i] = types.TypeString(res.Type, qf)
+	}
+
+	return typs
+}
+
+// Sig returns the signature without the func keyword like (a int) (string, error).
+func (m Method) Sig(qf types.Qualifier) string {
+	sig := "(" + m.ParamsDecl(qf) + ")"
+
+	typs := m.ResultTypes(qf)
+
+	switch {
+	case len(typs) == 0:
+		return sig
+	case len(typs) == 1 && m.Results[0].Name == "":
+		return sig + " " + typs[0]
+	}
+
+	decls := make([]string, len(typs))
+
+	for i, res := range m.Results {
+		if res.Name != "" {
+			decls[i] = res.Name + " " + typs[i]
+		} else {
+			decls[i] = typs[i]
+		}
+	}
+
+	return sig + " (" + strings.Join(decls, ", ") + ")"
+}
+
+// NamedSig returns the signature without the func keyword where the results are named by ResultNames.
+func (m Method) NamedSig(qf types.Qualifier) string {
+	sig := "(" + m.ParamsDecl(qf) + ")"
+
+	if len(m.Results) == 0 {
+		return sig
+	}
+
+	names := m.ResultNames()
+	typs := m.ResultTypes(qf)
+	decls := make([]string, len(typs))
+
+	for i := range typs {
+		decls[i] = names[i] + " " + typs[i]
+	}
+
+	return sig + " (" + strings.Join(decls, ", ") + ")"
+}
+
+// DocText returns the text of the method doc without the directives or empty string if there is no doc.
+func (m Method) DocText() string {
+	if m.Doc == nil {
+		return ""
+	}
+
+	return m.Doc.Text()
+}
+
+// DocComment returns the method doc as the comment lines or empty string if there is no doc.
+// The directives are omitted.
+func (m Method) DocComment() string {
+	text := m.DocText()
+	if text == "" {
+		return ""
+	}
+
+	var b strings.Builder
+
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		if line == "" {
+			b.WriteString("//\n")
+		} else {
+			b.WriteString("// " + line + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// Ctx returns the name of the leading context.Context parameter or empty string if there is no such parameter.
+func (m Method) Ctx() string {
+	if len(m.Params) == 0 || !IsContext(m.Params[0].Type) {
+		return ""
+	}
+
+	return m.Params[0].Name
+}
+
+// Err reports whether the trailing result is error.
+func (m Method) Err() bool {
+	return len(m.Results) > 0 && IsError(m.Results[len(m.Results)-1].Type)
+}
+
+// ErrName returns the name of the trailing error result like it is named by the NamedSig
+// or the empty string if the trailing result is not error.
+func (m Method) ErrName() string {
+	if !m.Err() {
+		return ""
+	}
+
+	names := m.ResultNames()
+
+	return names[len(names)-1]
+}
+
+// IsContext reports whether the type is context.Context.
+func IsContext(typ types.Type) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == "context" && obj.Name() == "Context"
+}
+
+// IsError reports whether the type is the error interface.
+func IsError(typ types.Type) bool {
+	return types.Identical(typ, types.Universe.Lookup("error").Type())
+}
+
+// InterfacesOf returns the models of the directives interfaces.
+// The locals are the identifiers declared by the generated methods such as the receiver name,
+// the methods parameters and results declared by the same names are renamed.
+// Identifiers of the interfaces are reserved at the imports,
+// so the packages referenced by the generated code are not shadowed by them.
+func InterfacesOf(gp []gen.Please, imports *gen.Imports, locals ...string) ([]Interface, error) {
+	ifaces := make([]Interface, 0, len(gp))
+
+	for _, pls := range gp {
+		iface, err := InterfaceOf(pls)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range iface.Methods {
+			iface.Methods[i].rename(locals)
+		}
+
+		imports.Reserve(iface.Idents()...)
+
+		ifaces = append(ifaces, iface)
+	}
+
+	return ifaces, nil
+}
+
+// SortMethods sorts the methods alphabetically like they are sorted at the method set.
+func (iface *Interface) SortMethods() {
+	slices.SortFunc(iface.Methods, func(a, b Method) int {
+		return strings.Compare(a.Func.Id(), b.Func.Id())
+	})
+}
+
+// TypeParamList returns the type parameters of the generic interface declaration.
+// It returns nil if the interface is not generic or is instantiated.
+func (iface Interface) TypeParamList() *types.TypeParamList {
+	return declaredTypeParams(iface.Named)
+}
+
+// Idents returns the type parameters names and the methods parameters and results names.
+func (iface Interface) Idents() []string {
+	var idents []string
+
+	for _, param := range iface.TypeParams {
+		idents = append(idents, param.Name)
+	}
+
+	for _, meth := range iface.Methods {
+		idents = append(idents, meth.ArgNames()...)
+		idents = append(idents, meth.ResultNames()...)
+	}
+
+	return idents
+}
+
+// CheckMethods returns an error positioned at the interface if any of the names is not its method.
+func (iface Interface) CheckMethods(names ...string) error {
+	for _, name := range names {
+		if !slices.ContainsFunc(iface.Methods, func(meth Method) bool { return meth.Name == name }) {
+			return fmt.Errorf("%s: unknown method %q", iface.Pos, name)
+		}
+	}
+
+	return nil
+}
+
+// CheckNotMethods returns an error positioned at the interface if any of the names is its method,
+// so the names of the generated methods and fields don't clash with the implemented methods.
+func (iface Interface) CheckNotMethods(names ...string) error {
+	for _, name := range names {
+		if slices.ContainsFunc(iface.Methods, func(meth Method) bool { return meth.Name == name }) {
+			return fmt.Errorf("%s: generated %s clashes with the method of the interface", iface.Pos, name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package analysis
+
+import (
+	"go/token"
+	"go/types"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+// Struct is the model of the struct type targeted by a directive.
+type Struct struct {
+	// Name is the name of the directive's type spec.
+	// It differs from the Named's name if the type spec is an alias.
+	Name string
+	// Pos is the position of the directive's type spec.
+	Pos token.Position
+	// TypeParams are the type parameters of the struct.
+	TypeParams []TypeParam
+
+	gen.Struct
+}
+
+// Field is a field of the struct.
+type Field = gen.Field
+
+// StructOf returns the model of the directive's struct.
+func StructOf(pls gen.Please) (Struct, error) {
+	strct, err := pls.TS.Struct()
+	if err != nil {
+		return Struct{}, err
+	}
+
+	return Struct{
+		Name:       pls.TS.Spec.Name.Name,
+		Pos:        pls.TS.Pkg.Fset.Position(pls.TS.Spec.Pos()),
+		TypeParams: typeParams(declaredTypeParams(strct.Named)),
+		Struct:     strct,
+	}, nil
+}
+
+// TypeParamList returns the type parameters of the generic struct declaration.
+// It returns nil if the struct is not generic or is instantiated.
+func (s Struct) TypeParamList() *types.TypeParamList {
+	return declaredTypeParams(s.Named)
+}
@@ -0,0 +1,18 @@
+package gen
+
+import "strings"
+
+// SortedUsage is the usage of the -sorted flag of the generators emitting the interface's methods.
+const SortedUsage = "emit methods in alphabetical order instead of the declaration order"
+
+// ArgSet is the comma separated list of the command arguments, it implements [flag.Value].
+type ArgSet []string
+
+func (a *ArgSet) String() string {
+	return strings.Join(*a, ",")
+}
+
+func (a *ArgSet) Set(s string) error {
+	*a = strings.Split(s, ",")
+	return nil
+}
@@ -0,0 +1,773 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/constant"
+	"go/format"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Code is a fragment of Go source composed by the code builder.
+// Fragments are rendered by [Source] which qualifies the referenced packages by its imports.
+type Code interface {
+	render(w *codeWriter)
+}
+
+type codeWriter struct {
+	buf     bytes.Buffer
+	imports *Imports
+}
+
+func (w *codeWriter) write(s string) {
+	w.buf.WriteString(s)
+}
+
+func (w *codeWriter) code(c Code) {
+	if c != nil {
+		c.render(w)
+	}
+}
+
+func (w *codeWriter) list(codes []Code, sep string) {
+	for i, c := range codes {
+		if i > 0 {
+			w.write(sep)
+		}
+
+		w.code(c)
+	}
+}
+
+func (w *codeWriter) block(stmts []Code) {
+	for _, stmt := range stmts {
+		w.code(stmt)
+		w.write("\n")
+	}
+}
+
+func (w *codeWriter) comment(text string) {
+	if text == "" {
+		return
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		if line == "" {
+			w.write("//\n")
+		} else {
+			w.write("// " + line + "\n")
+		}
+	}
+}
+
+func (w *codeWriter) typeParams(list *types.TypeParamList) {
+	if list.Len() == 0 {
+		return
+	}
+
+	w.write("[")
+
+	for i := range list.Len() {
+		if i > 0 {
+			w.write(", ")
+		}
+
+		w.write(list.At(i).Obj().Name() + " " + types.TypeString(list.At(i).Constraint(), w.imports.Qualifier))
+	}
+
+	w.write("]")
+}
+
+type codeFunc func(w *codeWriter)
+
+func (f codeFunc) render(w *codeWriter) {
+	f(w)
+}
+
+// Source is a Go source file composed by the code builder.
+type Source struct {
+	// Header is written before the package clause as is, e.g. the DO NOT EDIT comment.
+	Header string
+	// Package is the package name.
+	Package string
+	// Imports qualify the packages referenced by the declarations, all packages are qualified if it is nil.
+	Imports *Imports
+	// Decls are the top level declarations.
+	Decls []Code
+}
+
+// Bytes renders the gofmt-ed source.
+// It returns an error if the rendered source is not valid Go.
+func (s *Source) Bytes() ([]byte, error) {
+	if s.Imports == nil {
+		s.Imports = NewImports(nil, nil)
+	}
+
+	body := &codeWriter{imports: s.Imports}
+
+	for _, decl := range s.Decls {
+		body.code(decl)
+		body.write("\n")
+	}
+
+	buf := bytes.NewBuffer(nil)
+	buf.WriteString(s.Header)
+	buf.WriteString("package " + s.Package + "\n\n")
+	s.Imports.Write(buf)
+	buf.Write(body.buf.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format source: %w", err)
+	}
+
+	return src, nil
+}
+
+// Render returns the source of the code, the referenced packages are added to the imports.
+func (im *Imports) Render(c Code) string {
+	w := &codeWriter{imports: im}
+	w.code(c)
+
+	return w.buf.String()
+}
+
+// Ident is the identifier.
+func Ident(name string) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.write(name)
+	})
+}
+
+// Qual is the identifier declared by the package imported by the path.
+func Qual(path PkgPath, name string) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.write(string(w.imports.Add(path)) + "." + name)
+	})
+}
+
+// Type is the type qualified by the imports.
+func Type(typ types.Type) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.write(types.TypeString(typ, w.imports.Qualifier))
+	})
+}
+
+// Instance is the generic type instantiated by its own type parameters like S[T, U].
+// It is the plain name if there are no type parameters.
+func Instance(name string, list *types.TypeParamList) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.write(name)
+
+		if list.Len() == 0 {
+			return
+		}
+
+		names := make([]string, list.Len())
+
+		for i := range list.Len() {
+			names[i] = list.At(i).Obj().Name()
+		}
+
+		w.write("[" + strings.Join(names, ", ") + "]")
+	})
+}
+
+// Lit is the literal of the basic value or the constant.
+func Lit(v any) Code {
+	return codeFunc(func(w *codeWriter) {
+		switch v := v.(type) {
+		case string:
+			w.write(strconv.Quote(v))
+		case constant.Value:
+			w.write(v.ExactString())
+		default:
+			w.write(fmt.Sprintf("%#v", v))
+		}
+	})
+}
+
+// Raw is the source written as is.
+func Raw(src string) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.write(src)
+	})
+}
+
+// Duration is the literal of the duration in the largest whole unit like 5 * time.Second.
+func Duration(d time.Duration) Code {
+	if d == 0 {
+		return Lit(0)
+	}
+
+	units := []struct {
+		name string
+		d    time.Duration
+	}{
+		{"Hour", time.Hour},
+		{"Minute", time.Minute},
+		{"Second", time.Second},
+		{"Millisecond", time.Millisecond},
+		{"Microsecond", time.Microsecond},
+	}
+
+	for _, unit := range units {
+		if d%unit.d == 0 {
+			return Op(Lit(int64(d/unit.d)), "*", Qual("time", unit.name))
+		}
+	}
+
+	return Op(Lit(int64(d)), "*", Qual("time", "Nanosecond"))
+}
+
+// List is the comma separated list.
+func List(codes ...Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.list(codes, ", ")
+	})
+}
+
+// Sel is the selector expression like x.a.b.
+func Sel(x Code, names ...string) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.code(x)
+
+		for _, name := range names {
+			w.write("." + name)
+		}
+	})
+}
+
+// Call is the call expression.
+func Call(fn Code, args ...Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.code(fn)
+		w.write("(")
+		w.list(args, ", ")
+		w.write(")")
+	})
+}
+
+// Ptr is the pointer type or the pointer indirection.
+func Ptr(x Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.write("*")
+		w.code(x)
+	})
+}
+
+// Addr is the address operation.
+func Addr(x Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.write("&")
+		w.code(x)
+	})
+}
+
+// Op is the binary operation.
+func Op(x Code, op string, y Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.code(x)
+		w.write(" " + op + " ")
+		w.code(y)
+	})
+}
+
+// Not is the logical negation.
+func Not(x Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.write("!")
+		w.code(x)
+	})
+}
+
+// Composite is the composite literal, the elements are the values or the [KeyValue] pairs.
+func Composite(typ Code, elts ...Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.code(typ)
+		w.write("{")
+		w.list(elts, ", ")
+		w.write("}")
+	})
+}
+
+// KeyValue is the element of the composite literal.
+func KeyValue(key, value Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.code(key)
+		w.write(": ")
+		w.code(value)
+	})
+}
+
+// FieldDecl is the field of the struct type.
+type FieldDecl struct {
+	Doc string
+	// Name is the field name, the field is embedded if the name is empty.
+	Name string
+	Type Code
+	Tag  string
+}
+
+// StructType is the struct type.
+func StructType(fields ...FieldDecl) Code {
+	return codeFunc(func(w *codeWriter) {
+		if len(fields) == 0 {
+			w.write("struct{}")
+			return
+		}
+
+		w.write("struct {\n")
+
+		for _, field := range fields {
+			w.comment(field.Doc)
+
+			if field.Name != "" {
+				w.write(field.Name + " ")
+			}
+
+			w.code(field.Type)
+
+			switch {
+			case field.Tag == "":
+			case strings.Contains(field.Tag, "`"):
+				w.write(" " + strconv.Quote(field.Tag))
+			default:
+				w.write(" `" + field.Tag + "`")
+			}
+
+			w.write("\n")
+		}
+
+		w.write("}")
+	})
+}
+
+// FuncType is the func type of the signature.
+// Names of the parameters and the results are omitted if any of them is unnamed.
+func FuncType(sig *types.Signature) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.write("func(")
+		w.tuple(sig.Params(), sig.Variadic())
+		w.write(")")
+
+		switch results := sig.Results(); {
+		case results.Len() == 1 && results.At(0).Name() == "":
+			w.write(" " + types.TypeString(results.At(0).Type(), w.imports.Qualifier))
+		case results.Len() > 0:
+			w.write(" (")
+			w.tuple(results, false)
+			w.write(")")
+		}
+	})
+}
+
+func (w *codeWriter) tuple(tuple *types.Tuple, variadic bool) {
+	named := true
+
+	for i := range tuple.Len() {
+		if tuple.At(i).Name() == "" {
+			named = false
+		}
+	}
+
+	for i := range tuple.Len() {
+		if i > 0 {
+			w.write(", ")
+		}
+
+		if named {
+			w.write(tuple.At(i).Name() + " ")
+		}
+
+		if typ := tuple.At(i).Type(); variadic && i == tuple.Len()-1 {
+			w.write("..." + types.TypeString(typ.(*types.Slice).Elem(), w.imports.Qualifier))
+		} else {
+			w.write(types.TypeString(typ, w.imports.Qualifier))
+		}
+	}
+}
+
+// Comment is the comment statement, every line of the text is commented.
+func Comment(text string) Code {
+	return codeFunc(func(w *codeWriter) {
+		if text == "" {
+			return
+		}
+
+		// The trailing newline is written by the enclosing block.
+		w.comment(text)
+		w.buf.Truncate(w.buf.Len() - 1)
+	})
+}
+
+// Line is the statement composed of the codes, the empty line is written if there are no codes.
+// Statements are written on their own lines by the enclosing blocks.
+func Line(codes ...Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		for _, c := range codes {
+			w.code(c)
+		}
+	})
+}
+
+// Return is the return statement.
+func Return(results ...Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.write("return")
+
+		if len(results) > 0 {
+			w.write(" ")
+			w.list(results, ", ")
+		}
+	})
+}
+
+// Assign is the assignment statement.
+func Assign(lhs, rhs Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.code(lhs)
+		w.write(" = ")
+		w.code(rhs)
+	})
+}
+
+// Define is the short variable declaration.
+func Define(lhs, rhs Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.code(lhs)
+		w.write(" := ")
+		w.code(rhs)
+	})
+}
+
+// Defer is the defer statement.
+func Defer(call Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.write("defer ")
+		w.code(call)
+	})
+}
+
+// If is the if statement, init is the optional simple statement executed before the condition.
+func If(init, cond Code, body ...Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.write("if ")
+
+		if init != nil {
+			w.code(init)
+			w.write("; ")
+		}
+
+		w.code(cond)
+		w.write(" {\n")
+
+		w.block(body)
+		w.write("}")
+	})
+}
+
+// Range is the for statement ranging over x, the key and the value may be nil.
+func Range(key, value, x Code, body ...Code) Code {
+	return codeFunc(func(w *codeWriter) {
+		w.write("for ")
+
+		switch {
+		case value != nil:
+			if key == nil {
+				key = Ident("_")
+			}
+
+			w.code(key)
+			w.write(", ")
+			w.code(value)
+			w.write(" := ")
+		case key != nil:
+			w.code(key)
+			w.write(" := ")
+		}
+
+		w.write("range ")
+		w.code(x)
+		w.write(" {\n")
+
+		w.block(body)
+		w.write("}")
+	})
+}
+
+// TypeDecl declares the named type.
+type TypeDecl struct {
+	Doc        string
+	Name       string
+	TypeParams *types.TypeParamList
+	Type       Code
+}
+
+func (d TypeDecl) render(w *codeWriter) {
+	w.comment(d.Doc)
+	w.write("type " + d.Name)
+	w.typeParams(d.TypeParams)
+	w.write(" ")
+	w.code(d.Type)
+	w.write("\n")
+}
+
+// VarDecl declares the package variable, the type or the value may be nil.
+type VarDecl struct {
+	Doc   string
+	Name  string
+	Type  Code
+	Value Code
+}
+
+func (d VarDecl) render(w *codeWriter) {
+	valueDecl(w, "var", d.Doc, d.Name, d.Type, d.Value)
+}
+
+// ConstDecl declares the constant, the type may be nil.
+type ConstDecl struct {
+	Doc   string
+	Name  string
+	Type  Code
+	Value Code
+}
+
+func (d ConstDecl) render(w *codeWriter) {
+	valueDecl(w, "const", d.Doc, d.Name, d.Type, d.Value)
+}
+
+func valueDecl(w *codeWriter, keyword, doc, name string, typ, value Code) {
+	w.comment(doc)
+	w.write(keyword + " " + name)
+
+	if typ != nil {
+		w.write(" ")
+		w.code(typ)
+	}
+
+	if value != nil {
+		w.write(" = ")
+		w.code(value)
+	}
+
+	w.write("\n")
+}
+
+// Param is the parameter, the result or the receiver of the function, the name may be empty.
+type Param struct {
+	Name string
+	Type Code
+}
+
+// FuncDecl declares the function or the method if the receiver is set.
+type FuncDecl struct {
+	Doc  string
+	Recv *Param
+	Name string
+	// TypeParams are the type parameters of the function, methods can't have them.
+	TypeParams *types.TypeParamList
+	Params     []Param
+	// Variadic reports whether the last parameter is variadic, its type is the type of the elements.
+	Variadic bool
+	Results  []Param
+	Body     []Code
+}
+
+// FuncOf returns the declaration of the function with the signature.
+// Unnamed and blank parameters are named a0, a1, ... so the body can refer to them.
+// The names taken by the signature are suffixed by _1, _2, ...
+func FuncOf(name string, sig *types.Signature) FuncDecl {
+	taken := map[string]bool{}
+
+	for _, tuple := range []*types.Tuple{sig.Params(), sig.Results()} {
+		for i := range tuple.Len() {
+			taken[tuple.At(i).Name()] = true
+		}
+	}
+
+	params := make([]Param, sig.Params().Len())
+
+	for i := range sig.Params().Len() {
+		param := sig.Params().At(i)
+		typ := param.Type()
+
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			typ = typ.(*types.Slice).Elem()
+		}
+
+		params[i] = Param{Name: param.Name(), Type: Type(typ)}
+
+		if params[i].Name == "" || params[i].Name == "_" {
+			params[i].Name = FreeName("a"+strconv.Itoa(i), func(name string) bool { return taken[name] })
+			taken[params[i].Name] = true
+		}
+	}
+
+	results := make([]Param, sig.Results().Len())
+
+	for i := range sig.Results().Len() {
+		res := sig.Results().At(i)
+		results[i] = Param{Name: res.Name(), Type: Type(res.Type())}
+	}
+
+	return FuncDecl{
+		Name:       name,
+		TypeParams: sig.TypeParams(),
+		Params:     params,
+		Variadic:   sig.Variadic(),
+		Results:    results,
+	}
+}
+
+// NamedResults returns the declaration where all results are named r0, r1, ...
+// if any of them is unnamed or blank.
+// The names clashing with the parameters are suffixed by _1, _2, ...
+func (d FuncDecl) NamedResults() FuncDecl {
+	for _, res := range d.Results {
+		if res.Name != "" && res.Name != "_" {
+			continue
+		}
+
+		results := make([]Param, len(d.Results))
+
+		for i := range d.Results {
+			name := FreeName("r"+strconv.Itoa(i), func(name string) bool {
+				return slices.ContainsFunc(d.Params, func(p Param) bool { return p.Name == name }) ||
+					slices.ContainsFunc(results[:i], func(p Param) bool { return p.Name == name })
+			})
+
+			results[i] = Param{Name: name, Type: d.Results[i].Type}
+		}
+
+		d.Results = results
+
+		break
+	}
+
+	return d
+}
+
+// FreeName returns the name or the name suffixed by _1, _2, ... which is not taken.
+func FreeName(name string, taken func(name string) bool) string {
+	free := name
+
+	for i := 1; taken(free); i++ {
+		free = name + "_" + strconv.Itoa(i)
+	}
+
+	return free
+}
+
+// ParamNames returns the names of the parameters, they are used as the arguments passing them through.
+func (d FuncDecl) ParamNames() []Code {
+	names := make([]Code, len(d.Params))
+
+	for i, param := range d.Params {
+		name := param.Name
+		if d.Variadic && i == len(d.Params)-1 {
+			name += "..."
+		}
+
+		names[i] = Ident(name)
+	}
+
+	return names
+}
+
+// ResultNames returns the names of the results.
+func (d FuncDecl) ResultNames() []Code {
+	names := make([]Code, len(d.Results))
+
+	for i, res := range d.Results {
+		names[i] = Ident(res.Name)
+	}
+
+	return names
+}
+
+func (d FuncDecl) render(w *codeWriter) {
+	w.comment(d.Doc)
+	w.write("func ")
+
+	if d.Recv != nil {
+		w.write("(")
+		param(w, *d.Recv)
+		w.write(") ")
+	}
+
+	w.write(d.Name)
+	w.typeParams(d.TypeParams)
+	signature(w, d.Params, d.Variadic, d.Results)
+	w.write(" {\n")
+	w.block(d.Body)
+	w.write("}\n")
+}
+
+// FuncLit is the function literal.
+type FuncLit struct {
+	Params []Param
+	// Variadic reports whether the last parameter is variadic, its type is the type of the elements.
+	Variadic bool
+	Results  []Param
+	Body     []Code
+}
+
+func (f FuncLit) render(w *codeWriter) {
+	w.write("func")
+	signature(w, f.Params, f.Variadic, f.Results)
+	w.write(" {\n")
+	w.block(f.Body)
+	w.write("}")
+}
+
+func signature(w *codeWriter, params []Param, variadic bool, results []Param) {
+	w.write("(")
+
+	for i, p := range params {
+		if i > 0 {
+			w.write(", ")
+		}
+
+		if variadic && i == len(params)-1 {
+			p.Type = codeFunc(func(w *codeWriter) {
+				w.write("...")
+				w.code(params[i].Type)
+			})
+		}
+
+		param(w, p)
+	}
+
+	w.write(")")
+
+	switch {
+	case len(results) == 1 && results[0].Name == "":
+		w.write(" ")
+		w.code(results[0].Type)
+	case len(results) > 0:
+		w.write(" (")
+
+		for i, res := range results {
+			if i > 0 {
+				w.write(", ")
+			}
+
+			param(w, res)
+		}
+
+		w.write(")")
+	}
+}
+
+func param(w *codeWriter, p Param) {
+	if p.Name != "" {
+		w.write(p.Name + " ")
+	}
+
+	w.code(p.Type)
+}
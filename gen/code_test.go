@@ -0,0 +1,218 @@
+package gen_test
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+	"time"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSource_Bytes(t *testing.T) {
+	t.Parallel()
+
+	local := types.NewPackage("example.com/p", "p")
+	ctxPkg := types.NewPackage("context", "context")
+	ctxObj := types.NewTypeName(token.NoPos, ctxPkg, "Context", nil)
+	ctxType := types.NewNamed(ctxObj, types.NewInterfaceType(nil, nil), nil)
+
+	sig := types.NewSignatureType(nil, nil, nil,
+		types.NewTuple(
+			types.NewParam(token.NoPos, nil, "ctx", ctxType),
+			types.NewParam(token.NoPos, nil, "", types.NewSlice(types.Typ[types.String])),
+		),
+		types.NewTuple(
+			types.NewParam(token.NoPos, nil, "", types.Typ[types.Int]),
+			types.NewParam(token.NoPos, nil, "", types.Universe.Lookup("error").Type()),
+		),
+		true,
+	)
+
+	tparam := types.NewTypeParam(types.NewTypeName(token.NoPos, local, "T", nil), types.Universe.Lookup("any").Type())
+	tparams := types.NewNamed(types.NewTypeName(token.NoPos, local, "G", nil), types.NewStruct(nil, nil), nil)
+	tparams.SetTypeParams([]*types.TypeParam{tparam})
+
+	fn := gen.FuncOf("Do", sig).NamedResults()
+	fn.Doc = "Do does.\n\nIt returns the error."
+	fn.Recv = &gen.Param{Name: "g", Type: gen.Ptr(gen.Instance("G", tparams.TypeParams()))}
+	fn.Body = []gen.Code{
+		gen.If(
+			gen.Define(gen.Ident("err"), gen.Call(gen.Sel(gen.Ident("ctx"), "Err"))),
+			gen.Op(gen.Ident("err"), "!=", gen.Ident("nil")),
+			gen.Return(gen.Lit(0), gen.Call(gen.Qual("fmt", "Errorf"), gen.Lit("do: %w"), gen.Ident("err"))),
+		),
+		gen.Line(),
+		gen.Return(gen.Call(gen.Ident("len"), gen.Ident("a1")), gen.Ident("nil")),
+	}
+
+	src := gen.Source{
+		Header:  "// Code generated; DO NOT EDIT.\n\n",
+		Package: "p",
+		Imports: gen.NewImports(local, nil),
+		Decls: []gen.Code{
+			gen.VarDecl{
+				Doc:   "ErrFoo is an error.",
+				Name:  "ErrFoo",
+				Value: gen.Call(gen.Qual("errors", "New"), gen.Lit("foo")),
+			},
+			gen.TypeDecl{
+				Doc:        "G is generic.",
+				Name:       "G",
+				TypeParams: tparams.TypeParams(),
+				Type: gen.StructType(
+					gen.FieldDecl{Doc: "V is a value.", Name: "V", Type: gen.Ident("T"), Tag: `json:"v"`},
+					gen.FieldDecl{Name: "fn", Type: gen.FuncType(sig)},
+				),
+			},
+			fn,
+		},
+	}
+
+	data, err := src.Bytes()
+	require.NoError(t, err)
+
+	assert.Equal(t, `// Code generated; DO NOT EDIT.
+
+package p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrFoo is an error.
+var ErrFoo = errors.New("foo")
+
+// G is generic.
+type G[T any] struct {
+	// V is a value.
+	V  T `+"`json:\"v\"`"+`
+	fn func(context.Context, ...string) (int, error)
+}
+
+// Do does.
+//
+// It returns the error.
+func (g *G[T]) Do(ctx context.Context, a1 ...string) (r0 int, r1 error) {
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("do: %w", err)
+	}
+
+	return len(a1), nil
+}
+`, string(data))
+}
+
+func TestSource_Bytes_closure(t *testing.T) {
+	t.Parallel()
+
+	src := gen.Source{
+		Package: "p",
+		Decls: []gen.Code{
+			gen.FuncDecl{
+				Name:     "Apply",
+				Params:   []gen.Param{{Name: "opts", Type: gen.Raw("func(*int)")}},
+				Variadic: true,
+				Results:  []gen.Param{{Type: gen.Raw("func() int")}},
+				Body: []gen.Code{
+					gen.Define(gen.Ident("v"), gen.Lit(0)),
+					gen.Line(),
+					gen.Range(nil, gen.Ident("opt"), gen.Ident("opts"),
+						gen.Line(gen.Call(gen.Ident("opt"), gen.Addr(gen.Ident("v")))),
+					),
+					gen.Line(),
+					gen.Return(gen.FuncLit{
+						Results: []gen.Param{{Type: gen.Ident("int")}},
+						Body:    []gen.Code{gen.Return(gen.Ident("v"))},
+					}),
+				},
+			},
+		},
+	}
+
+	data, err := src.Bytes()
+	require.NoError(t, err)
+
+	assert.Equal(t, `package p
+
+func Apply(opts ...func(*int)) func() int {
+	v := 0
+
+	for _, opt := range opts {
+		opt(&v)
+	}
+
+	return func() int {
+		return v
+	}
+}
+`, string(data))
+}
+
+func TestSource_Bytes_invalid(t *testing.T) {
+	t.Parallel()
+
+	src := gen.Source{
+		Package: "p",
+		Decls:   []gen.Code{gen.Raw("func {")},
+	}
+
+	_, err := src.Bytes()
+	assert.Error(t, err)
+}
+
+func TestFuncDecl_NamedResults(t *testing.T) {
+	t.Parallel()
+
+	sig := types.NewSignatureType(nil, nil, nil,
+		types.NewTuple(types.NewParam(token.NoPos, nil, "r0", types.Typ[types.Int])),
+		types.NewTuple(
+			types.NewParam(token.NoPos, nil, "", types.Typ[types.Int]),
+			types.NewParam(token.NoPos, nil, "", types.Universe.Lookup("error").Type()),
+		),
+		false,
+	)
+
+	fn := gen.FuncOf("Do", sig).NamedResults()
+
+	require.Len(t, fn.Results, 2)
+	assert.Equal(t, "r0_1", fn.Results[0].Name)
+	assert.Equal(t, "r1", fn.Results[1].Name)
+}
+
+func TestFuncOf_placeholders(t *testing.T) {
+	t.Parallel()
+
+	sig := types.NewSignatureType(nil, nil, nil,
+		types.NewTuple(
+			types.NewParam(token.NoPos, nil, "a1", types.Typ[types.Int]),
+			types.NewParam(token.NoPos, nil, "_", types.Typ[types.String]),
+			types.NewParam(token.NoPos, nil, "", types.Typ[types.Bool]),
+		),
+		types.NewTuple(types.NewParam(token.NoPos, nil, "a2", types.Typ[types.Int])),
+		false,
+	)
+
+	fn := gen.FuncOf("Do", sig)
+
+	require.Len(t, fn.Params, 3)
+	assert.Equal(t, "a1", fn.Params[0].Name)
+	assert.Equal(t, "a1_1", fn.Params[1].Name)
+	assert.Equal(t, "a2_1", fn.Params[2].Name)
+}
+
+func TestDuration(t *testing.T) {
+	t.Parallel()
+
+	imports := gen.NewImports(nil, nil)
+
+	assert.Equal(t, "0", imports.Render(gen.Duration(0)))
+	assert.Equal(t, "90 * time.Second", imports.Render(gen.Duration(90*time.Second)))
+	assert.Equal(t, "2 * time.Hour", imports.Render(gen.Duration(2*time.Hour)))
+	assert.Equal(t, "1500 * time.Nanosecond", imports.Render(gen.Duration(1500)))
+	assert.Equal(t, 1, imports.Len())
+}
@@ -1,8 +1,15 @@
 package gen
 
 import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"go/format"
 	"iter"
 	"maps"
+	"slices"
+	"strings"
 )
 
 // File is a result of the code generation.
@@ -14,6 +21,8 @@ type File struct {
 }
 
 // IterateFiles returns iterator of grouped commands by the filename.
+// Commands of the file are ordered by the positions of their type specs,
+// so the generated code doesn't depend on the scanning order.
 func IterateFiles(pls []Please) iter.Seq2[string, []Please] {
 	m := map[string][]Please{}
 
@@ -21,5 +30,95 @@ func IterateFiles(pls []Please) iter.Seq2[string, []Please] {
 		m[p.Filename] = append(m[p.Filename], p)
 	}
 
+	for _, gp := range m {
+		slices.SortStableFunc(gp, func(a, b Please) int {
+			return cmp.Compare(a.pos(), b.pos())
+		})
+	}
+
 	return maps.All(m)
 }
+
+// GenerateFiles returns the files generated for the commands grouped by the filename.
+// Every file is named by the generator and starts by the DO NOT EDIT header and the package clause,
+// generate writes the rest of the file for the commands of the file. The files are gofmt-ed.
+func GenerateFiles(
+	ctx context.Context, name GeneratorName, pls []Please,
+	generate func(buf *bytes.Buffer, gp []Please) error,
+) ([]File, error) {
+	var files []File
+
+	buf := bytes.NewBuffer(nil)
+
+	for filename, gp := range IterateFiles(pls) {
+		buf.Reset()
+		buf.WriteString(gp[0].FormatDoNotEditHeader(name))
+		buf.WriteString(gp[0].FormatPkg())
+
+		if err := generate(buf, gp); err != nil {
+			return nil, fmt.Errorf("generate: %w", err)
+		}
+
+		out := gp[0].FormatGeneratorFileName(name, strings.HasSuffix(filename, "_test.go"))
+
+		data, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("format source %s: %w", out, err)
+		}
+
+		files = append(files, File{
+			Name: out,
+			Data: data,
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("context is closed: %w", ctx.Err())
+		default:
+		}
+	}
+
+	return files, nil
+}
+
+// GenerateSources is like [GenerateFiles] but generate declares the code of the file at the source
+// which imports and package are the ones of the commands' package.
+// Out is the name of the generated file.
+func GenerateSources(
+	ctx context.Context, name GeneratorName, pls []Please,
+	generate func(src *Source, gp []Please, out string) error,
+) ([]File, error) {
+	var files []File
+
+	for filename, gp := range IterateFiles(pls) {
+		out := gp[0].FormatGeneratorFileName(name, strings.HasSuffix(filename, "_test.go"))
+
+		src := Source{
+			Header:  gp[0].FormatDoNotEditHeader(name),
+			Package: gp[0].TS.Pkg.Name,
+			Imports: NewImports(gp[0].TS.Pkg.Types, gp[0].Imports),
+		}
+
+		if err := generate(&src, gp, out); err != nil {
+			return nil, fmt.Errorf("generate: %w", err)
+		}
+
+		data, err := src.Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("generate: %w", err)
+		}
+
+		files = append(files, File{
+			Name: out,
+			Data: data,
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("context is closed: %w", ctx.Err())
+		default:
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,75 @@
+package gen_test
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"go/ast"
+	"go/token"
+	"slices"
+	"testing"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestGenerateFiles(t *testing.T) {
+	t.Parallel()
+
+	pkg := &packages.Package{Name: "p"}
+
+	please := func(filename, typename string, pos int) gen.Please {
+		return gen.Please{
+			Filename: filename,
+			TS: &gen.TypeSpec{
+				Pkg:  pkg,
+				Spec: &ast.TypeSpec{Name: &ast.Ident{Name: typename, NamePos: token.Pos(pos)}},
+			},
+		}
+	}
+
+	gp := []gen.Please{
+		please("/p/a.go", "B", 2),
+		please("/p/a.go", "A", 1),
+		please("/p/a_test.go", "T", 1),
+	}
+
+	files, err := gen.GenerateFiles(context.Background(), "stub", gp, func(buf *bytes.Buffer, gp []gen.Please) error {
+		for _, pls := range gp {
+			buf.WriteString("type " + pls.TS.Spec.Name.Name + "   struct{}\n")
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	slices.SortFunc(files, func(a, b gen.File) int { return cmp.Compare(a.Name, b.Name) })
+
+	header := "// Code generated by \"genpls:stub\"; DO NOT EDIT.\n// github.com/WinPooh32/genpls\n\npackage p\n\n"
+
+	require.Len(t, files, 2)
+	assert.Equal(t, "/p/stub_gen.go", files[0].Name)
+	assert.Equal(t, header+"type A struct{}\ntype B struct{}\n", string(files[0].Data))
+	assert.Equal(t, "/p/stub_gen_test.go", files[1].Name)
+	assert.Equal(t, header+"type T struct{}\n", string(files[1].Data))
+}
+
+func TestGenerateFiles_invalid(t *testing.T) {
+	t.Parallel()
+
+	gp := []gen.Please{{
+		Filename: "/p/a.go",
+		TS: &gen.TypeSpec{
+			Pkg:  &packages.Package{Name: "p"},
+			Spec: &ast.TypeSpec{Name: &ast.Ident{Name: "A"}},
+		},
+	}}
+
+	_, err := gen.GenerateFiles(context.Background(), "stub", gp, func(buf *bytes.Buffer, _ []gen.Please) error {
+		buf.WriteString("func {\n")
+		return nil
+	})
+	assert.ErrorContains(t, err, "format source /p/stub_gen.go: ")
+}
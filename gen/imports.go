@@ -0,0 +1,244 @@
+package gen
+
+import (
+	"bytes"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+const (
+	dotImport   PkgName = "."
+	blankImport PkgName = "_"
+)
+
+// Imports manages the imports of a generated file.
+//
+// Every package is imported by a unique name which doesn't shadow and isn't shadowed by
+// the reserved identifiers: the package scope of the local package, the predeclared identifiers and
+// the identifiers reserved by the generator such as parameters names.
+// Names are assigned in the order of adding, so the same input produces the same imports.
+type Imports struct {
+	local    *types.Package
+	aliases  map[PkgPath]PkgName
+	reserved map[string]bool
+	names    map[PkgPath]PkgName
+	paths    map[PkgName]PkgPath
+	blanks   map[PkgPath]bool
+	// actual are the names declared by the package clauses, an assigned name differing from it is written as the alias.
+	actual map[PkgPath]PkgName
+}
+
+// NewImports returns imports of the code generated into the local package.
+// Types of the local package are unqualified, nil local qualifies all types.
+// Packages are preferably named by the aliases of the source file imports, dot and blank aliases are ignored.
+func NewImports(local *types.Package, aliases map[PkgPath]PkgName) *Imports {
+	im := &Imports{
+		local:    local,
+		aliases:  aliases,
+		reserved: map[string]bool{},
+		names:    map[PkgPath]PkgName{},
+		paths:    map[PkgName]PkgPath{},
+		blanks:   map[PkgPath]bool{},
+		actual:   map[PkgPath]PkgName{},
+	}
+
+	im.Reserve(types.Universe.Names()...)
+
+	if local != nil {
+		im.Reserve(local.Scope().Names()...)
+	}
+
+	return im
+}
+
+// Reserve reserves identifiers declared by the generated code.
+// It must be called before the packages are added, the names of already added packages are not changed.
+func (im *Imports) Reserve(idents ...string) {
+	for _, ident := range idents {
+		im.reserved[ident] = true
+	}
+}
+
+// Add imports the package by the path and returns the name which the package is referenced by.
+// The actual name of the package is assumed from the path.
+func (im *Imports) Add(path PkgPath) PkgName {
+	return im.add(path, assumedName(path))
+}
+
+// AddDot imports the package with dot alias, its exported identifiers are referenced without qualification.
+func (im *Imports) AddDot(path PkgPath) {
+	if _, ok := im.names[path]; !ok {
+		im.names[path] = dotImport
+	}
+}
+
+// AddBlank imports the package for its side effects only.
+func (im *Imports) AddBlank(path PkgPath) {
+	im.blanks[path] = true
+}
+
+// Qualifier qualifies the types by the names of their imported packages.
+func (im *Imports) Qualifier(pkg *types.Package) string {
+	if pkg == im.local {
+		// local imports are unqualified.
+		return ""
+	}
+
+	name := im.add(PkgPath(pkg.Path()), PkgName(pkg.Name()))
+	if name == dotImport {
+		return ""
+	}
+
+	return string(name)
+}
+
+// Funcs returns the template functions, {{pkg "path"}} adds the package and returns its name.
+func (im *Imports) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"pkg": im.Add,
+	}
+}
+
+// TemplateFuncs are placeholders of the [Imports.Funcs] functions for parsing templates.
+var TemplateFuncs = template.FuncMap{
+	"pkg": func(PkgPath) PkgName {
+		panic("gen: pkg template function is called without imports")
+	},
+}
+
+// Execute executes the template with the [Imports.Funcs] functions.
+func (im *Imports) Execute(buf *bytes.Buffer, tmpl *template.Template, data any) error {
+	tmpl, err := tmpl.Clone()
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Funcs(im.Funcs()).Execute(buf, data)
+}
+
+// Len returns the number of imported packages.
+func (im *Imports) Len() int {
+	n := len(im.names)
+
+	for path := range im.blanks {
+		if _, ok := im.names[path]; !ok {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Write writes the import declaration sorted by the packages paths.
+// Nothing is written if there are no imported packages.
+func (im *Imports) Write(buf *bytes.Buffer) {
+	if im.Len() == 0 {
+		return
+	}
+
+	specs := map[PkgPath]PkgName{}
+
+	for path := range im.blanks {
+		specs[path] = blankImport
+	}
+
+	for path, name := range im.names {
+		if name == im.actual[path] {
+			name = ""
+		}
+
+		specs[path] = name
+	}
+
+	buf.WriteString("import (\n")
+
+	for _, path := range slices.Sorted(maps.Keys(specs)) {
+		buf.WriteByte('\t')
+
+		if name := specs[path]; name != "" {
+			buf.WriteString(string(name))
+			buf.WriteByte(' ')
+		}
+
+		buf.WriteString(strconv.Quote(string(path)))
+		buf.WriteByte('\n')
+	}
+
+	buf.WriteString(")\n\n")
+}
+
+// add imports the package and returns its assigned name.
+func (im *Imports) add(path PkgPath, actual PkgName) PkgName {
+	if name, ok := im.names[path]; ok {
+		return name
+	}
+
+	im.actual[path] = actual
+
+	base := actual
+	if alias := im.aliases[path]; alias != "" && alias != dotImport && alias != blankImport {
+		base = alias
+	}
+
+	name := base
+
+	for i := 1; !im.free(name); i++ {
+		name = base + "_" + PkgName(strconv.Itoa(i))
+	}
+
+	im.names[path] = name
+	im.paths[name] = path
+
+	return name
+}
+
+func (im *Imports) free(name PkgName) bool {
+	_, taken := im.paths[name]
+
+	return !taken && !im.reserved[string(name)]
+}
+
+// assumedName returns the package name assumed by the import path:
+// the last path element without the major version suffix, the gopkg.in version and the go- prefix.
+func assumedName(path PkgPath) PkgName {
+	elems := strings.Split(string(path), "/")
+	name := elems[len(elems)-1]
+
+	if len(elems) > 1 && isMajorVersion(name) {
+		name = elems[len(elems)-2]
+	}
+
+	name, _, _ = strings.Cut(name, ".")
+	name = strings.TrimPrefix(name, "go-")
+
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+
+		return -1
+	}, name)
+
+	if !token.IsIdentifier(name) {
+		name = "pkg" + name
+	}
+
+	return PkgName(name)
+}
+
+func isMajorVersion(elem string) bool {
+	v, ok := strings.CutPrefix(elem, "v")
+	if !ok || v == "" {
+		return false
+	}
+
+	_, err := strconv.Atoi(v)
+
+	return err == nil
+}
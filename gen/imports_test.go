@@ -0,0 +1,83 @@
+package gen_test
+
+import (
+	"bytes"
+	"go/types"
+	"testing"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImports(t *testing.T) {
+	t.Parallel()
+
+	local := types.NewPackage("example.com/p", "p")
+	local.Scope().Insert(types.NewTypeName(0, local, "fmt", types.Typ[types.Int]))
+
+	imports := gen.NewImports(local, map[gen.PkgPath]gen.PkgName{
+		"io":              "stdio",
+		"example.com/dot": ".",
+	})
+	imports.Reserve("errors")
+
+	assert.Equal(t, "", imports.Qualifier(local))
+	assert.Equal(t, "stdio", imports.Qualifier(types.NewPackage("io", "io")))
+	assert.Equal(t, "types", imports.Qualifier(types.NewPackage("go/types", "types")))
+	assert.Equal(t, "types_1", imports.Qualifier(types.NewPackage("example.com/types", "types")))
+	assert.Equal(t, "dot", imports.Qualifier(types.NewPackage("example.com/dot", "dot")))
+
+	// The name is stable for the same path.
+	assert.Equal(t, "types_1", imports.Qualifier(types.NewPackage("example.com/types", "types")))
+
+	// Names reserved by the generator and declared by the local package are not used.
+	assert.Equal(t, gen.PkgName("errors_1"), imports.Add("errors"))
+	assert.Equal(t, gen.PkgName("fmt_1"), imports.Add("fmt"))
+	assert.Equal(t, gen.PkgName("rand"), imports.Add("math/rand/v2"))
+	assert.Equal(t, gen.PkgName("yaml"), imports.Add("gopkg.in/yaml.v3"))
+
+	imports.AddDot("example.com/matchers")
+	imports.AddBlank("embed")
+
+	assert.Equal(t, 10, imports.Len())
+
+	buf := bytes.NewBuffer(nil)
+	imports.Write(buf)
+
+	assert.Equal(t, `import (
+	_ "embed"
+	errors_1 "errors"
+	"example.com/dot"
+	. "example.com/matchers"
+	types_1 "example.com/types"
+	fmt_1 "fmt"
+	"go/types"
+	"gopkg.in/yaml.v3"
+	stdio "io"
+	"math/rand/v2"
+)
+
+`, buf.String())
+}
+
+func TestImports_nilLocal(t *testing.T) {
+	t.Parallel()
+
+	imports := gen.NewImports(nil, nil)
+
+	assert.Equal(t, "p", imports.Qualifier(types.NewPackage("example.com/p", "p")))
+
+	buf := bytes.NewBuffer(nil)
+	imports.Write(buf)
+
+	assert.Equal(t, "import (\n\t\"example.com/p\"\n)\n\n", buf.String())
+}
+
+func TestImports_empty(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.NewBuffer(nil)
+	gen.NewImports(nil, nil).Write(buf)
+
+	assert.Empty(t, buf.String())
+}
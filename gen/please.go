@@ -1,6 +1,7 @@
 package gen
 
 import (
+	"go/token"
 	"path/filepath"
 	"strings"
 )
@@ -61,3 +62,12 @@ func (pls *Please) FormatDoNotEditHeader(name GeneratorName) string {
 func (pls *Please) FormatPkg() string {
 	return "package " + pls.TS.Pkg.Name + "\n\n"
 }
+
+// pos returns the position of the type spec or zero if there is no type spec.
+func (pls *Please) pos() token.Pos {
+	if pls.TS == nil || pls.TS.Spec == nil {
+		return token.NoPos
+	}
+
+	return pls.TS.Spec.Pos()
+}
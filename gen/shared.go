@@ -0,0 +1,96 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// SharedDeclared reports whether the declarations shared by the code generated for the commands
+// are declared by the generated file of the package, so they must not be declared again.
+// It is the case of the commands of the package test files if the other package files have the generator's directives.
+// Shared reports whether the directive with the arguments declares the shared declarations, nil means it always does.
+func SharedDeclared(name GeneratorName, gp []Please, shared func(args []string) bool) bool {
+	if !strings.HasSuffix(gp[0].Filename, testSuffix+".go") {
+		return false
+	}
+
+	pkg := gp[0].TS.Pkg
+
+	for _, file := range pkg.Syntax {
+		if strings.HasSuffix(pkg.Fset.Position(file.Pos()).Filename, testSuffix+".go") {
+			continue
+		}
+
+		for _, args := range directives(file, name) {
+			if shared == nil || shared(args) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// CheckShared returns an error if any of the names of the declarations shared by the generated code
+// is already declared by the package at a file which is not generated by the generator.
+func CheckShared(name GeneratorName, gp []Please, names ...string) error {
+	pls := gp[0]
+
+	generated := []string{
+		pls.FormatGeneratorFileName(name, false),
+		pls.FormatGeneratorFileName(name, true),
+	}
+
+	for _, ident := range names {
+		obj := pls.TS.Pkg.Types.Scope().Lookup(ident)
+		if obj == nil {
+			continue
+		}
+
+		if pos := pls.TS.Pkg.Fset.Position(obj.Pos()); !slices.Contains(generated, filepath.Clean(pos.Filename)) {
+			return fmt.Errorf("%s: %s declared by the %s code is already declared at %s",
+				pls.TS.Pkg.Fset.Position(pls.TS.Spec.Pos()), ident, name, pos)
+		}
+	}
+
+	return nil
+}
+
+// directives returns the arguments of the generator's directives at the docs of the file's type declarations.
+func directives(file *ast.File, name GeneratorName) [][]string {
+	var args [][]string
+
+	add := func(doc *ast.CommentGroup) {
+		if doc == nil {
+			return
+		}
+
+		for _, line := range doc.List {
+			rest, ok := strings.CutPrefix(line.Text, "//"+name.Command())
+			if ok && (rest == "" || rest[0] == ' ') {
+				args = append(args, strings.Fields(rest))
+			}
+		}
+	}
+
+	for _, decl := range file.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.TYPE {
+			continue
+		}
+
+		add(decl.Doc)
+
+		for _, spec := range decl.Specs {
+			if spec, ok := spec.(*ast.TypeSpec); ok {
+				add(spec.Doc)
+			}
+		}
+	}
+
+	return args
+}
@@ -0,0 +1,122 @@
+package gen_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+// loadPackage parses and type checks the package files by their names.
+func loadPackage(t *testing.T, files map[string]string) *packages.Package {
+	t.Helper()
+
+	pkg := &packages.Package{Name: "p", Fset: token.NewFileSet()}
+
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		file, err := parser.ParseFile(pkg.Fset, name, files[name], parser.ParseComments)
+		require.NoError(t, err)
+
+		pkg.Syntax = append(pkg.Syntax, file)
+	}
+
+	typs, err := new(types.Config).Check("p", pkg.Fset, pkg.Syntax, nil)
+	require.NoError(t, err)
+
+	pkg.Types = typs
+
+	return pkg
+}
+
+// pleaseOf returns the command of the named type spec of the package.
+func pleaseOf(t *testing.T, pkg *packages.Package, typename string) []gen.Please {
+	t.Helper()
+
+	obj := pkg.Types.Scope().Lookup(typename)
+	require.NotNil(t, obj)
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range decl.Specs {
+				if spec, ok := spec.(*ast.TypeSpec); ok && spec.Name.Pos() == obj.Pos() {
+					return []gen.Please{{
+						Filename: pkg.Fset.Position(spec.Pos()).Filename,
+						TS:       &gen.TypeSpec{Pkg: pkg, Spec: spec},
+					}}
+				}
+			}
+		}
+	}
+
+	require.FailNow(t, "type spec is not found", typename)
+
+	return nil
+}
+
+func TestSharedDeclared(t *testing.T) {
+	t.Parallel()
+
+	pkg := loadPackage(t, map[string]string{
+		"/p/a.go": `package p
+
+//genpls:recover -log
+type A interface{}
+
+//genpls:recovering
+type B interface{}
+`,
+		"/p/a_test.go": `package p
+
+//genpls:recover
+type T interface{}
+`,
+	})
+
+	log := func(args []string) bool { return slices.Contains(args, "-log") }
+	sorted := func(args []string) bool { return slices.Contains(args, "-sorted") }
+
+	assert.False(t, gen.SharedDeclared("recover", pleaseOf(t, pkg, "A"), nil))
+	assert.True(t, gen.SharedDeclared("recover", pleaseOf(t, pkg, "T"), nil))
+	assert.True(t, gen.SharedDeclared("recover", pleaseOf(t, pkg, "T"), log))
+	assert.False(t, gen.SharedDeclared("recover", pleaseOf(t, pkg, "T"), sorted))
+	assert.False(t, gen.SharedDeclared("recoverin", pleaseOf(t, pkg, "T"), nil))
+}
+
+func TestCheckShared(t *testing.T) {
+	t.Parallel()
+
+	pkg := loadPackage(t, map[string]string{
+		"/p/a.go": `package p
+
+//genpls:recover
+type A interface{}
+
+type PanicError struct{}
+`,
+		"/p/recover_gen.go": `package p
+
+type RecoverA struct{}
+`,
+	})
+
+	gp := pleaseOf(t, pkg, "A")
+
+	require.NoError(t, gen.CheckShared("recover", gp, "RecoverA", "Unknown"))
+
+	err := gen.CheckShared("recover", gp, "RecoverA", "PanicError")
+	require.Error(t, err)
+	assert.Equal(t, "/p/a.go:4:6: PanicError declared by the recover code is already declared at /p/a.go:6:6", err.Error())
+}
@@ -0,0 +1,134 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// TagKey is the key of the struct tag controlling the generators like `genpls:"required"`.
+const TagKey = "genpls"
+
+// Struct is the typed view of the struct type spec.
+type Struct struct {
+	// Named is the named struct type. Aliases are resolved.
+	Named *types.Named
+	// Type is the underlying struct type.
+	Type *types.Struct
+	// Fields are the fields of the struct in the declaration order.
+	Fields []Field
+}
+
+// Field is a field of the struct.
+type Field struct {
+	Name string
+	Type types.Type
+	// Tag is the parsed struct tag.
+	Tag reflect.StructTag
+	// Doc is the field doc comment, the fields declared by the same line share it.
+	Doc      *ast.CommentGroup
+	Exported bool
+	Embedded bool
+}
+
+// Struct returns the typed view of the type spec if its type is a struct.
+func (ts *TypeSpec) Struct() (Struct, error) {
+	name := ts.Spec.Name.Name
+	position := ts.Pkg.Fset.Position(ts.Spec.Pos())
+
+	object := ts.Pkg.Types.Scope().Lookup(name)
+	if object == nil {
+		return Struct{}, fmt.Errorf("%s: object %s not found", position, name)
+	}
+
+	named, ok := types.Unalias(object.Type()).(*types.Named)
+	if !ok {
+		return Struct{}, fmt.Errorf("%s: type %q must be a struct", position, name)
+	}
+
+	strct, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return Struct{}, fmt.Errorf("%s: type %q must be a struct", position, name)
+	}
+
+	docs := fieldDocs(ts.Spec)
+
+	fields := make([]Field, strct.NumFields())
+
+	for i := range strct.NumFields() {
+		v := strct.Field(i)
+
+		fields[i] = Field{
+			Name:     v.Name(),
+			Type:     v.Type(),
+			Tag:      reflect.StructTag(strct.Tag(i)),
+			Exported: v.Exported(),
+			Embedded: v.Embedded(),
+		}
+
+		if i < len(docs) {
+			fields[i].Doc = docs[i]
+		}
+	}
+
+	return Struct{
+		Named:  named,
+		Type:   strct,
+		Fields: fields,
+	}, nil
+}
+
+// Field returns the field by the name.
+func (s Struct) Field(name string) (Field, bool) {
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+
+	return Field{}, false
+}
+
+// Options returns the comma separated options of the field's genpls tag.
+func (f Field) Options() []string {
+	tag, ok := f.Tag.Lookup(TagKey)
+	if !ok || tag == "" {
+		return nil
+	}
+
+	opts := strings.Split(tag, ",")
+
+	for i := range opts {
+		opts[i] = strings.TrimSpace(opts[i])
+	}
+
+	return opts
+}
+
+// HasOption reports whether the field's genpls tag has the option.
+func (f Field) HasOption(opt string) bool {
+	return slices.Contains(f.Options(), opt)
+}
+
+// fieldDocs returns docs of the struct fields in the declaration order.
+// The docs of the alias's fields are not available, the fields are declared by the aliased type spec.
+func fieldDocs(spec *ast.TypeSpec) []*ast.CommentGroup {
+	strct, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return nil
+	}
+
+	var docs []*ast.CommentGroup
+
+	for _, field := range strct.Fields.List {
+		// Embedded field has no names.
+		for range max(len(field.Names), 1) {
+			docs = append(docs, field.Doc)
+		}
+	}
+
+	return docs
+}
@@ -0,0 +1,128 @@
+package gen_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+const structSrc = `package p
+
+import "io"
+
+type S[T any] struct {
+	// A doc
+	A, b T ` + "`json:\"a\" db:\"a_col\"`" + `
+	io.Reader
+
+	// C doc
+	C []string ` + "`genpls:\"required, -\"`" + `
+}
+
+type Alias = S[int]
+
+type NotStruct int
+`
+
+// typeSpec returns the type spec declared at the struct source.
+func typeSpec(t *testing.T, name string) *gen.TypeSpec {
+	t.Helper()
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "p.go", structSrc, parser.ParseComments)
+	require.NoError(t, err)
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+
+	pkg, err := conf.Check("p", fset, []*ast.File{file}, nil)
+	require.NoError(t, err)
+
+	var spec *ast.TypeSpec
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		if ts, ok := node.(*ast.TypeSpec); ok && ts.Name.Name == name {
+			spec = ts
+		}
+
+		return spec == nil
+	})
+	require.NotNil(t, spec)
+
+	return &gen.TypeSpec{
+		Pkg: &packages.Package{
+			Name:   "p",
+			Fset:   fset,
+			Syntax: []*ast.File{file},
+			Types:  pkg,
+		},
+		Spec: spec,
+	}
+}
+
+func TestTypeSpec_Struct(t *testing.T) {
+	t.Parallel()
+
+	strct, err := typeSpec(t, "S").Struct()
+	require.NoError(t, err)
+
+	assert.Equal(t, "S", strct.Named.Obj().Name())
+	require.Len(t, strct.Fields, 4)
+
+	a := strct.Fields[0]
+	assert.Equal(t, "A", a.Name)
+	assert.Equal(t, "T", a.Type.String())
+	assert.True(t, a.Exported)
+	assert.False(t, a.Embedded)
+	assert.Equal(t, "a", a.Tag.Get("json"))
+	assert.Equal(t, "a_col", a.Tag.Get("db"))
+	assert.Equal(t, "A doc\n", a.Doc.Text())
+
+	b := strct.Fields[1]
+	assert.Equal(t, "b", b.Name)
+	assert.False(t, b.Exported)
+	assert.Equal(t, "A doc\n", b.Doc.Text())
+
+	reader := strct.Fields[2]
+	assert.Equal(t, "Reader", reader.Name)
+	assert.True(t, reader.Embedded)
+	assert.Equal(t, "io.Reader", reader.Type.String())
+	assert.Nil(t, reader.Doc)
+
+	c, ok := strct.Field("C")
+	require.True(t, ok)
+	assert.Equal(t, "C doc\n", c.Doc.Text())
+	assert.Equal(t, []string{"required", "-"}, c.Options())
+	assert.True(t, c.HasOption("required"))
+	assert.False(t, c.HasOption("skip"))
+	assert.Nil(t, a.Options())
+
+	_, ok = strct.Field("D")
+	assert.False(t, ok)
+}
+
+func TestTypeSpec_Struct_alias(t *testing.T) {
+	t.Parallel()
+
+	strct, err := typeSpec(t, "Alias").Struct()
+	require.NoError(t, err)
+
+	assert.Equal(t, "p.S[int]", strct.Named.String())
+	require.Len(t, strct.Fields, 4)
+	assert.Equal(t, "int", strct.Fields[0].Type.String())
+}
+
+func TestTypeSpec_Struct_notStruct(t *testing.T) {
+	t.Parallel()
+
+	_, err := typeSpec(t, "NotStruct").Struct()
+	assert.ErrorContains(t, err, `p.go:16:6: type "NotStruct" must be a struct`)
+}
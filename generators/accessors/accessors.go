@@ -0,0 +1,247 @@
+package accessors
+
+import (
+	"context"
+	"fmt"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/gen/analysis"
+)
+
+const (
+	// optSkip is the genpls tag option of the field which has no accessors.
+	optSkip = "-"
+	// optSet is the genpls tag option of the field which has the setter.
+	optSet = "set"
+	// optReadOnly is the genpls tag option of the field which has no setter.
+	optReadOnly = "readonly"
+)
+
+// fieldInfo is the field accessed by the generated methods.
+type fieldInfo struct {
+	gen.Field
+	// Getter is the name of the getter method or empty if the getter clashes with the declared method.
+	Getter string
+	// Setter is the name of the setter method or empty if there is no setter.
+	Setter string
+}
+
+// structInfo is the struct with the accessors.
+type structInfo struct {
+	analysis.Struct
+	// Recv is the name of the methods' receiver.
+	Recv   string
+	Fields []fieldInfo
+}
+
+func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]gen.File, error) {
+	return gen.GenerateSources(ctx, name, gp, generate)
+}
+
+func generate(src *gen.Source, gp []gen.Please, out string) error {
+	infos := make([]structInfo, 0, len(gp))
+
+	for _, pls := range gp {
+		cfg, err := parseArgs(pls.Args, config{})
+		if err != nil {
+			return fmt.Errorf("parse command arguments: %w", err)
+		}
+
+		info, err := analyze(pls, cfg, out)
+		if err != nil {
+			return err
+		}
+
+		// Receivers must not be shadowed by the imported packages.
+		src.Imports.Reserve(info.Recv, "v")
+
+		infos = append(infos, info)
+	}
+
+	for _, info := range infos {
+		src.Decls = append(src.Decls, accessorsDecls(info)...)
+	}
+
+	return nil
+}
+
+// analyze returns the struct's fields with the names of their accessors.
+// Unexported fields have unexported accessors like getField, so the private state is not exposed to other packages.
+// The accessors clashing with the methods declared out of the generated file are not generated.
+func analyze(pls gen.Please, cfg config, out string) (structInfo, error) {
+	strct, err := analysis.StructOf(pls)
+	if err != nil {
+		return structInfo{}, fmt.Errorf("analyze: %w", err)
+	}
+
+	recv, declared := declaredMethods(pls, out)
+	if recv == "" {
+		recv = strings.ToLower(string([]rune(strct.Name)[0]))
+	}
+
+	for _, field := range strct.Fields {
+		declared[field.Name] = true
+	}
+
+	fields := make([]fieldInfo, 0, len(strct.Fields))
+	methods := map[string]string{}
+
+	for _, field := range strct.Fields {
+		switch {
+		case field.Name == "_" || field.HasOption(optSkip):
+			continue
+		}
+
+		// accessor returns the method name or empty if the method is already declared.
+		accessor := func(prefix string) (string, error) {
+			if !field.Exported {
+				prefix = strings.ToLower(prefix)
+			}
+
+			method := prefix + analysis.UpperFirst(field.Name)
+
+			if declared[method] {
+				return "", nil
+			}
+
+			if other, ok := methods[method]; ok {
+				return "", fmt.Errorf("%s: fields %s and %s have the same accessor %s",
+					strct.Pos, other, field.Name, method)
+			}
+
+			methods[method] = field.Name
+
+			return method, nil
+		}
+
+		info := fieldInfo{Field: field}
+
+		if info.Getter, err = accessor("Get"); err != nil {
+			return structInfo{}, err
+		}
+
+		if (cfg.Set || field.HasOption(optSet)) && !field.HasOption(optReadOnly) {
+			if info.Setter, err = accessor("Set"); err != nil {
+				return structInfo{}, err
+			}
+		}
+
+		if info.Getter != "" || info.Setter != "" {
+			fields = append(fields, info)
+		}
+	}
+
+	return structInfo{
+		Struct: strct,
+		Recv:   recv,
+		Fields: fields,
+	}, nil
+}
+
+// declaredMethods returns the receiver name and the names of the struct's methods declared out of the generated file.
+// The receiver name is empty if there is no named receiver.
+func declaredMethods(pls gen.Please, out string) (recv string, names map[string]bool) {
+	names = map[string]bool{}
+
+	for _, fs := range pls.TS.Methods {
+		filename := pls.TS.Pkg.Fset.Position(fs.Decl.Pos()).Filename
+		if filepath.Clean(filename) == filepath.Clean(out) {
+			continue
+		}
+
+		names[fs.Decl.Name.Name] = true
+
+		if recv != "" {
+			continue
+		}
+
+		if field := fs.Decl.Recv.List[0]; len(field.Names) > 0 && field.Names[0].Name != "_" {
+			recv = field.Names[0].Name
+		}
+	}
+
+	return recv, names
+}
+
+// accessorsDecls returns the declarations of the getters and setters.
+func accessorsDecls(info structInfo) []gen.Code {
+	typeParams := info.TypeParamList()
+	recv := &gen.Param{Name: info.Recv, Type: gen.Ptr(gen.Instance(info.Name, typeParams))}
+	x := gen.Ident(info.Recv)
+
+	param := "v"
+	if info.Recv == param {
+		param = "value"
+	}
+
+	var decls []gen.Code
+
+	for _, field := range info.Fields {
+		typ := gen.Type(field.Type)
+
+		if field.Getter != "" {
+			doc := field.Getter + " returns " + field.Name + " or the zero value if " + info.Recv + " is nil."
+			if field.Doc != nil {
+				doc += "\n\n" + field.Doc.Text()
+			}
+
+			decls = append(decls, gen.FuncDecl{
+				Doc:     doc,
+				Recv:    recv,
+				Name:    field.Getter,
+				Results: []gen.Param{{Type: typ}},
+				Body: []gen.Code{
+					gen.If(nil, gen.Op(x, "==", gen.Ident("nil")),
+						gen.Return(zero(field.Type)),
+					),
+					gen.Line(),
+					gen.Return(gen.Sel(x, field.Name)),
+				},
+			})
+		}
+
+		if field.Setter != "" {
+			decls = append(decls, gen.FuncDecl{
+				Doc:    field.Setter + " sets " + field.Name + ".",
+				Recv:   recv,
+				Name:   field.Setter,
+				Params: []gen.Param{{Name: param, Type: typ}},
+				Body: []gen.Code{
+					gen.Assign(gen.Sel(x, field.Name), gen.Ident(param)),
+				},
+			})
+		}
+	}
+
+	return decls
+}
+
+// zero returns the zero value expression of the type.
+func zero(typ types.Type) gen.Code {
+	switch under := typ.Underlying().(type) {
+	case *types.Basic:
+		switch info := under.Info(); {
+		case info&types.IsString != 0:
+			return gen.Lit("")
+		case info&types.IsBoolean != 0:
+			return gen.Ident("false")
+		case info&types.IsNumeric != 0:
+			return gen.Lit(0)
+		}
+
+		return gen.Ident("nil")
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature:
+		return gen.Ident("nil")
+	case *types.Interface:
+		if _, ok := types.Unalias(typ).(*types.TypeParam); ok {
+			return gen.Ptr(gen.Call(gen.Ident("new"), gen.Type(typ)))
+		}
+
+		return gen.Ident("nil")
+	}
+
+	return gen.Composite(gen.Type(typ))
+}
@@ -0,0 +1,24 @@
+package accessors
+
+import (
+	"flag"
+	"fmt"
+)
+
+type config struct {
+	Set bool
+}
+
+func parseArgs(arguments []string, defaultValue config) (config, error) {
+	var cfg config
+
+	flagset := flag.NewFlagSet("", flag.ContinueOnError)
+
+	flagset.BoolVar(&cfg.Set, "set", defaultValue.Set, "generate setters of all fields")
+
+	if err := flagset.Parse(arguments); err != nil {
+		return config{}, fmt.Errorf("flagset: Parse: %w", err)
+	}
+
+	return cfg, nil
+}
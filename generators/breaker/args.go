@@ -0,0 +1,56 @@
+package breaker
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+type config struct {
+	Failures  int
+	Cooldown  time.Duration
+	Successes int
+	Rate      float64
+	Burst     int
+	Skip      gen.ArgSet
+	Sorted    bool
+}
+
+func parseArgs(arguments []string, defaultValue config) (config, error) {
+	var cfg config
+
+	flagset := flag.NewFlagSet("", flag.ContinueOnError)
+
+	flagset.IntVar(&cfg.Failures, "failures", defaultValue.Failures,
+		"default number of consecutive failures opening the circuit")
+	flagset.DurationVar(&cfg.Cooldown, "cooldown", defaultValue.Cooldown, "default time the circuit stays open")
+	flagset.IntVar(&cfg.Successes, "successes", defaultValue.Successes,
+		"default number of successful calls closing the half-open circuit")
+	flagset.Float64Var(&cfg.Rate, "rate", defaultValue.Rate,
+		"default number of calls allowed per second, 0 means calls are not limited by default")
+	flagset.IntVar(&cfg.Burst, "burst", defaultValue.Burst, "default number of calls allowed at once by the limiter")
+	flagset.Var(&cfg.Skip, "skip", "comma separated list of methods which are not guarded")
+	flagset.BoolVar(&cfg.Sorted, "sorted", defaultValue.Sorted, gen.SortedUsage)
+
+	if err := flagset.Parse(arguments); err != nil {
+		return config{}, fmt.Errorf("flagset: Parse: %w", err)
+	}
+
+	if cfg.Failures < 1 || cfg.Successes < 1 || cfg.Burst < 1 {
+		return config{}, errors.New("failures, successes and burst must be positive")
+	}
+
+	if cfg.Cooldown <= 0 {
+		return config{}, fmt.Errorf("cooldown %s must be positive", cfg.Cooldown)
+	}
+
+	if cfg.Rate < 0 || math.IsNaN(cfg.Rate) || math.IsInf(cfg.Rate, 0) {
+		return config{}, fmt.Errorf("rate %v must be finite and not negative", cfg.Rate)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,140 @@
+package breaker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/gen/analysis"
+)
+
+func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]gen.File, error) {
+	return gen.GenerateFiles(ctx, name, gp, func(buf *bytes.Buffer, gp []gen.Please) error {
+		return generate(buf, name, gp)
+	})
+}
+
+type methInfo struct {
+	analysis.DecoratedMethod
+	Skip bool
+}
+
+func generate(buf *bytes.Buffer, name gen.GeneratorName, gp []gen.Please) error {
+	imports := gen.NewImports(gp[0].TS.Pkg.Types, gp[0].Imports)
+	imports.Reserve(
+		"ErrCircuitOpen", "ErrRateLimited", "BreakerPolicy", "breakerState", "breakerClosed", "breakerOpen",
+		"breakerHalfOpen", "breaker", "newBreaker", "b", "now", "err", "failed", "w", "v", "policy",
+		"generation", "panicked", "state",
+	)
+
+	ifaces, err := analysis.InterfacesOf(gp, imports, "w", "generation", "panicked")
+	if err != nil {
+		return fmt.Errorf("analyze: %w", err)
+	}
+
+	if err := gen.CheckShared(
+		name, gp, "ErrCircuitOpen", "ErrRateLimited", "BreakerPolicy", "breakerState", "breakerClosed", "breakerOpen",
+		"breakerHalfOpen", "breaker", "newBreaker",
+	); err != nil {
+		return err
+	}
+
+	body := bytes.NewBuffer(nil)
+
+	if !gen.SharedDeclared(name, gp, nil) {
+		if err := imports.Execute(body, tmplBreaker, nil); err != nil {
+			return fmt.Errorf("execute template: %w", err)
+		}
+	}
+
+	for i, pls := range gp {
+		cfg, err := parseArgs(pls.Args, config{
+			Failures:  5,
+			Cooldown:  30 * time.Second,
+			Successes: 1,
+			Burst:     1,
+		})
+		if err != nil {
+			return fmt.Errorf("parse command arguments: %w", err)
+		}
+
+		if cfg.Sorted {
+			ifaces[i].SortMethods()
+		}
+
+		methInfos, err := analyze(ifaces[i], imports, cfg)
+		if err != nil {
+			return fmt.Errorf("analyze: %w", err)
+		}
+
+		if err := genBreaker(body, imports, ifaces[i], methInfos, cfg); err != nil {
+			return err
+		}
+	}
+
+	imports.Write(buf)
+	buf.Write(body.Bytes())
+
+	return nil
+}
+
+func analyze(iface analysis.Interface, imports *gen.Imports, cfg config) ([]methInfo, error) {
+	qf := imports.Qualifier
+
+	skip := make(map[string]bool, len(cfg.Skip))
+	for _, name := range cfg.Skip {
+		skip[name] = true
+	}
+
+	if err := iface.CheckMethods(cfg.Skip...); err != nil {
+		return nil, err
+	}
+
+	methInfos := make([]methInfo, 0, len(iface.Methods))
+
+	for _, meth := range iface.Methods {
+		methInfos = append(methInfos, methInfo{
+			DecoratedMethod: analysis.DecoratedMethodOf(meth, qf),
+			Skip:            skip[meth.Name],
+		})
+	}
+
+	return methInfos, nil
+}
+
+func genBreaker(
+	buf *bytes.Buffer, imports *gen.Imports, iface analysis.Interface, methInfos []methInfo, cfg config,
+) error {
+	var rate string
+	if cfg.Rate != 0 {
+		rate = strconv.FormatFloat(cfg.Rate, 'g', -1, 64)
+	}
+
+	data := struct {
+		analysis.Decorator
+		Methods   []methInfo
+		Failures  int
+		Cooldown  string
+		Successes int
+		// Rate is the default rate or empty if there is no default.
+		Rate  string
+		Burst int
+	}{
+		Decorator: analysis.DecoratorOf("Breaker", iface, imports.Qualifier),
+		Methods:   methInfos,
+		Failures:  cfg.Failures,
+		Cooldown:  imports.Render(gen.Duration(cfg.Cooldown)),
+		Successes: cfg.Successes,
+		Rate:      rate,
+		Burst:     cfg.Burst,
+	}
+
+	if err := imports.Execute(buf, tmpl, data); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	return nil
+}
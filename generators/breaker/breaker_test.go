@@ -0,0 +1,33 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/internal/gentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const src = `package p
+
+import "context"
+
+type Getter interface {
+	Get(ctx context.Context, id string) (string, error)
+}
+`
+
+func TestGenerate_rate(t *testing.T) {
+	t.Parallel()
+
+	for _, rate := range []string{"-1", "NaN", "Inf", "-Inf"} {
+		_, err := Generate(context.Background(), "breaker", []gen.Please{gentest.Please(t, src, "Getter", "-rate="+rate)})
+		assert.ErrorContains(t, err, "must be finite and not negative", rate)
+	}
+
+	files, err := Generate(context.Background(), "breaker", []gen.Please{gentest.Please(t, src, "Getter", "-rate=0.5")})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
@@ -0,0 +1,224 @@
+package breaker
+
+import (
+	"text/template"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+const tmplBreakerText = `var (
+	// ErrCircuitOpen is returned by the breaker wrappers while the circuit is open.
+	ErrCircuitOpen = {{pkg "errors"}}.New("circuit breaker is open")
+	// ErrRateLimited is returned by the breaker wrappers if the rate limit is exceeded.
+	ErrRateLimited = {{pkg "errors"}}.New("rate limit is exceeded")
+)
+
+// BreakerPolicy configures the breaker wrappers.
+// Zero fields are set to the defaults of the wrapper.
+type BreakerPolicy struct {
+	// Failures is the number of consecutive failures opening the circuit.
+	Failures int
+	// Cooldown is the time the circuit stays open before letting a probe call through.
+	Cooldown {{pkg "time"}}.Duration
+	// Successes is the number of successful probe calls closing the half-open circuit.
+	Successes int
+	// Rate is the number of calls allowed per second.
+	// If Rate is zero, the wrapper's default rate is used.
+	// Calls are not limited if Rate is negative or if both Rate and the default rate are zero.
+	Rate float64
+	// Burst is the maximum number of calls allowed at once by the limiter.
+	Burst int
+	// IsFailure reports whether the call failed with err counts as a failure.
+	// All errors are failures if IsFailure is nil.
+	IsFailure func(err error) bool
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// breaker is a circuit breaker with an optional token bucket limiter.
+type breaker struct {
+	mu         {{pkg "sync"}}.Mutex
+	policy     BreakerPolicy
+	state      breakerState
+	failures   int
+	successes  int
+	probing    bool
+	// generation is changed by every state transition, completions of calls allowed by other generations are stale.
+	generation uint64
+	openedAt   {{pkg "time"}}.Time
+	tokens     float64
+	refilledAt {{pkg "time"}}.Time
+}
+
+func newBreaker(policy BreakerPolicy) *breaker {
+	return &breaker{
+		policy:     policy,
+		tokens:     float64(policy.Burst),
+		refilledAt: {{pkg "time"}}.Now(),
+	}
+}
+
+// allow returns the generation the call is allowed by or an error if the call must not proceed.
+// Allowed calls must be reported with done.
+func (b *breaker) allow() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := {{pkg "time"}}.Now()
+
+	if b.state == breakerOpen && now.Sub(b.openedAt) >= b.policy.Cooldown {
+		b.transit(breakerHalfOpen)
+		b.successes = 0
+	}
+
+	if b.state == breakerOpen || b.state == breakerHalfOpen && b.probing {
+		return 0, ErrCircuitOpen
+	}
+
+	if b.policy.Rate > 0 {
+		b.tokens = min(b.tokens+now.Sub(b.refilledAt).Seconds()*b.policy.Rate, float64(b.policy.Burst))
+		b.refilledAt = now
+
+		if b.tokens < 1 {
+			return 0, ErrRateLimited
+		}
+
+		b.tokens--
+	}
+
+	if b.state == breakerHalfOpen {
+		b.probing = true
+	}
+
+	return b.generation, nil
+}
+
+// done records the result of the call allowed by the generation, the panicked call is failed.
+// Results of the calls allowed before the last state transition are ignored.
+func (b *breaker) done(generation uint64, err error, panicked bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+
+	failed := panicked || err != nil && (b.policy.IsFailure == nil || b.policy.IsFailure(err))
+
+	switch b.state {
+	case breakerClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+
+		b.failures++
+		if b.failures >= b.policy.Failures {
+			b.open()
+		}
+	case breakerHalfOpen:
+		b.probing = false
+
+		if failed {
+			b.open()
+			return
+		}
+
+		b.successes++
+		if b.successes >= b.policy.Successes {
+			b.transit(breakerClosed)
+		}
+	case breakerOpen:
+	}
+}
+
+func (b *breaker) open() {
+	b.transit(breakerOpen)
+	b.openedAt = {{pkg "time"}}.Now()
+	b.failures = 0
+}
+
+func (b *breaker) transit(state breakerState) {
+	b.state = state
+	b.probing = false
+	b.generation++
+}
+
+`
+
+//nolint:lll
+const tmplText = `// *{{.ConcrName}} implements {{.InterfaceName}}.
+type {{.ConcrName}}{{.TypeParamsDecl}} struct {
+	v       {{.InterfaceName}}{{.TypeParams}}
+	breaker *breaker
+}
+
+// New{{.ConcrName}} returns a new *{{.ConcrName}} guarding calls of v according to policy.
+func New{{.ConcrName}}{{.TypeParamsDecl}}(v {{.InterfaceName}}{{.TypeParams}}, policy BreakerPolicy) (*{{.ConcrName}}{{.TypeParams}}, error) {
+	if v == nil {
+		return nil, {{pkg "errors"}}.New("v is nil")
+	}
+
+	if policy.Failures <= 0 {
+		policy.Failures = {{.Failures}}
+	}
+
+	if policy.Cooldown <= 0 {
+		policy.Cooldown = {{.Cooldown}}
+	}
+
+	if policy.Successes <= 0 {
+		policy.Successes = {{.Successes}}
+	}
+{{- if .Rate}}
+
+	if policy.Rate == 0 {
+		policy.Rate = {{.Rate}}
+	}
+{{- end}}
+
+	if policy.Burst <= 0 {
+		policy.Burst = {{.Burst}}
+	}
+
+	return &{{.ConcrName}}{{.TypeParams}}{
+		v:       v,
+		breaker: newBreaker(policy),
+	}, nil
+}
+{{range .Methods}}
+{{.Doc}}func (w *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+{{- if and .Err (not .Skip)}}
+	var generation uint64
+
+	if generation, {{.Err}} = w.breaker.allow(); {{.Err}} != nil {
+		return {{.Results}}
+	}
+
+	panicked := true
+
+	defer func() {
+		w.breaker.done(generation, {{.Err}}, panicked)
+	}()
+
+	{{.Results}} = w.v.{{.Name}}({{.Args}})
+	panicked = false
+
+	return {{.Results}}
+{{- else}}
+	{{if .Ret}}return {{end}}w.v.{{.Name}}({{.Args}})
+{{- end}}
+}
+{{end}}
+`
+
+var (
+	tmpl        = template.Must(template.New("breaker").Funcs(gen.TemplateFuncs).Parse(tmplText))
+	tmplBreaker = template.Must(template.New("state").Funcs(gen.TemplateFuncs).Parse(tmplBreakerText))
+)
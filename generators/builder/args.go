@@ -0,0 +1,51 @@
+package builder
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+type config struct {
+	Name     string
+	Pkg      string
+	Dir      string
+	Required gen.ArgSet
+}
+
+// Filename returns the path of the generated file relative to the struct's directory.
+// The test file is generated for the struct declared by the test file.
+func (cfg *config) Filename(test bool) string {
+	name := cfg.Name
+
+	switch {
+	case strings.HasSuffix(name, ".go"):
+	case test:
+		name += "_gen_test.go"
+	default:
+		name += "_gen.go"
+	}
+
+	return filepath.Join(cfg.Dir, name)
+}
+
+func parseArgs(arguments []string, defaultValue config) (config, error) {
+	var cfg config
+
+	flagset := flag.NewFlagSet("", flag.ContinueOnError)
+
+	flagset.StringVar(&cfg.Name, "name", defaultValue.Name, "file name")
+	flagset.StringVar(&cfg.Pkg, "pkg", defaultValue.Pkg,
+		"package name of the builder generated outside of the struct's package")
+	flagset.StringVar(&cfg.Dir, "dir", defaultValue.Dir, "package dir path")
+	flagset.Var(&cfg.Required, "required", "comma separated list of fields names which must be set before build")
+
+	if err := flagset.Parse(arguments); err != nil {
+		return config{}, fmt.Errorf("flagset: Parse: %w", err)
+	}
+
+	return cfg, nil
+}
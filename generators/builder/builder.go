@@ -0,0 +1,264 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/gen/analysis"
+)
+
+const (
+	// optRequired is the genpls tag option of the field which must be set before build.
+	optRequired = "required"
+	// optSkip is the genpls tag option of the field which has no setter.
+	optSkip = "-"
+)
+
+// fieldInfo is the field set by the builder.
+type fieldInfo struct {
+	gen.Field
+	// Setter is the name of the setter method.
+	Setter string
+	// Required reports whether the field must be set before build.
+	Required bool
+}
+
+func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]gen.File, error) {
+	files := make([]gen.File, 0, len(gp))
+
+	for _, pls := range gp {
+		cfg, err := parseArgs(pls.Args, config{
+			Name: strings.ToLower(pls.TS.Spec.Name.Name) + "_builder",
+			Dir:  ".",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("parse command arguments: %w", err)
+		}
+
+		test := strings.HasSuffix(pls.Filename, "_test.go")
+		filename := filepath.Clean(filepath.Join(filepath.Dir(pls.Filename), cfg.Filename(test)))
+
+		// Builders generated outside of the struct's directory belong to another package.
+		local := filepath.Dir(filename) == filepath.Dir(pls.Filename)
+
+		src := gen.Source{
+			Header: pls.FormatDoNotEditHeader(name),
+		}
+
+		if local {
+			src.Package = pls.TS.Pkg.Name
+			src.Imports = gen.NewImports(pls.TS.Pkg.Types, pls.Imports)
+		} else {
+			src.Package = cfg.Pkg
+			if src.Package == "" {
+				src.Package = filepath.Base(filepath.Dir(filename))
+			}
+
+			src.Imports = gen.NewImports(nil, pls.Imports)
+		}
+
+		if err := generate(&src, pls, cfg, local); err != nil {
+			return nil, fmt.Errorf("generate: %w", err)
+		}
+
+		data, err := src.Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("generate: %w", err)
+		}
+
+		files = append(files, gen.File{
+			Name: filename,
+			Data: data,
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("context is closed: %w", ctx.Err())
+		default:
+		}
+	}
+
+	return files, nil
+}
+
+func generate(src *gen.Source, pls gen.Please, cfg config, local bool) error {
+	src.Imports.Reserve("b", "v")
+
+	strct, err := analysis.StructOf(pls)
+	if err != nil {
+		return fmt.Errorf("analyze: %w", err)
+	}
+
+	if !local && !token.IsExported(strct.Name) {
+		return fmt.Errorf("%s: unexported struct %s can't be built outside of package %s",
+			strct.Pos, strct.Name, strct.Named.Obj().Pkg().Path())
+	}
+
+	fields, err := analyze(strct, cfg, local)
+	if err != nil {
+		return err
+	}
+
+	src.Decls = append(src.Decls, builderDecls(strct, fields)...)
+
+	return nil
+}
+
+// analyze returns the fields set by the builder.
+// Unexported fields can be set by the builder generated in the struct's package only.
+func analyze(strct analysis.Struct, cfg config, local bool) ([]fieldInfo, error) {
+	for _, name := range cfg.Required {
+		if _, ok := strct.Field(name); !ok {
+			return nil, fmt.Errorf("%s: required field %s is not found", strct.Pos, name)
+		}
+	}
+
+	fields := make([]fieldInfo, 0, len(strct.Fields))
+	setters := map[string]string{}
+
+	for _, field := range strct.Fields {
+		required := field.HasOption(optRequired) || slices.Contains(cfg.Required, field.Name)
+
+		switch {
+		case field.Name == "_":
+			continue
+		case field.HasOption(optSkip):
+			if required {
+				return nil, fmt.Errorf("%s: required field %s is skipped", strct.Pos, field.Name)
+			}
+
+			continue
+		case !local && !field.Exported:
+			if required {
+				return nil, fmt.Errorf("%s: required unexported field %s can't be set outside of package %s",
+					strct.Pos, field.Name, strct.Named.Obj().Pkg().Path())
+			}
+
+			continue
+		}
+
+		setter := "With" + analysis.UpperFirst(field.Name)
+
+		if other, ok := setters[setter]; ok {
+			return nil, fmt.Errorf("%s: fields %s and %s have the same setter %s", strct.Pos, other, field.Name, setter)
+		}
+
+		setters[setter] = field.Name
+
+		fields = append(fields, fieldInfo{
+			Field:    field,
+			Setter:   setter,
+			Required: required,
+		})
+	}
+
+	return fields, nil
+}
+
+// builderDecls returns the declarations of the builder type, its constructor and methods.
+func builderDecls(strct analysis.Struct, fields []fieldInfo) []gen.Code {
+	builder := strct.Name + "Builder"
+
+	constructor := "New" + builder
+	if !token.IsExported(strct.Name) {
+		constructor = "new" + analysis.UpperFirst(builder)
+	}
+
+	typeParams := strct.TypeParamList()
+	typ := gen.Type(analysis.Instance(strct.Named, typeParams))
+	recv := &gen.Param{Name: "b", Type: gen.Ptr(gen.Instance(builder, typeParams))}
+
+	builderFields := []gen.FieldDecl{{Name: "v", Type: typ}}
+
+	for _, field := range fields {
+		if field.Required {
+			builderFields = append(builderFields, gen.FieldDecl{Name: setFlag(field), Type: gen.Ident("bool")})
+		}
+	}
+
+	decls := []gen.Code{
+		gen.TypeDecl{
+			Doc:        "*" + builder + " builds " + strct.Name + " by the chainable setters.",
+			Name:       builder,
+			TypeParams: typeParams,
+			Type:       gen.StructType(builderFields...),
+		},
+		gen.FuncDecl{
+			Doc:        constructor + " returns a new *" + builder + " building the zero " + strct.Name + ".",
+			Name:       constructor,
+			TypeParams: typeParams,
+			Results:    []gen.Param{{Type: recv.Type}},
+			Body: []gen.Code{
+				gen.Return(gen.Addr(gen.Composite(gen.Instance(builder, typeParams)))),
+			},
+		},
+	}
+
+	for _, field := range fields {
+		doc := field.Setter + " sets " + field.Name + "."
+		if field.Doc != nil {
+			doc += "\n\n" + field.Doc.Text()
+		}
+
+		body := []gen.Code{
+			gen.Assign(gen.Sel(gen.Ident("b"), "v", field.Name), gen.Ident("v")),
+		}
+
+		if field.Required {
+			body = append(body, gen.Assign(gen.Sel(gen.Ident("b"), setFlag(field)), gen.Ident("true")))
+		}
+
+		decls = append(decls, gen.FuncDecl{
+			Doc:     doc,
+			Recv:    recv,
+			Name:    field.Setter,
+			Params:  []gen.Param{{Name: "v", Type: gen.Type(field.Type)}},
+			Results: []gen.Param{{Type: recv.Type}},
+			Body:    append(body, gen.Line(), gen.Return(gen.Ident("b"))),
+		})
+	}
+
+	doc := "Build returns the built " + strct.Name + "."
+
+	var body []gen.Code
+
+	for _, field := range fields {
+		if !field.Required {
+			continue
+		}
+
+		body = append(body,
+			gen.If(nil, gen.Not(gen.Sel(gen.Ident("b"), setFlag(field))),
+				gen.Return(
+					gen.Composite(typ),
+					gen.Call(gen.Qual("errors", "New"), gen.Lit(builder+": required field "+field.Name+" is not set")),
+				),
+			),
+			gen.Line(),
+		)
+	}
+
+	if len(body) > 0 {
+		doc += "\nIt returns an error if any of the required fields is not set."
+	}
+
+	decls = append(decls, gen.FuncDecl{
+		Doc:     doc,
+		Recv:    recv,
+		Name:    "Build",
+		Results: []gen.Param{{Type: typ}, {Type: gen.Ident("error")}},
+		Body:    append(body, gen.Return(gen.Sel(gen.Ident("b"), "v"), gen.Ident("nil"))),
+	})
+
+	return decls
+}
+
+// setFlag returns the name of the builder's field reporting whether the required field is set.
+func setFlag(field fieldInfo) string {
+	return "has" + analysis.UpperFirst(field.Name)
+}
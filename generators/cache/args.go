@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+type config struct {
+	TTL     time.Duration
+	Size    int
+	Methods gen.ArgSet
+	Sorted  bool
+}
+
+func parseArgs(arguments []string, defaultValue config) (config, error) {
+	var cfg config
+
+	flagset := flag.NewFlagSet("", flag.ContinueOnError)
+
+	flagset.DurationVar(&cfg.TTL, "ttl", defaultValue.TTL, "default time to live of cached results")
+	flagset.IntVar(&cfg.Size, "size", defaultValue.Size, "maximum number of cached results per method")
+	flagset.Var(&cfg.Methods, "methods",
+		"comma separated list of cached methods with optional ttl as method:ttl, other methods are not cached")
+	flagset.BoolVar(&cfg.Sorted, "sorted", defaultValue.Sorted, gen.SortedUsage)
+
+	if err := flagset.Parse(arguments); err != nil {
+		return config{}, fmt.Errorf("flagset: Parse: %w", err)
+	}
+
+	if len(cfg.Methods) == 0 {
+		var unused []string
+
+		flagset.Visit(func(f *flag.Flag) {
+			if f.Name == "ttl" || f.Name == "size" {
+				unused = append(unused, "-"+f.Name)
+			}
+		})
+
+		if len(unused) > 0 {
+			return config{}, fmt.Errorf("%s without cached methods, list them by -methods", strings.Join(unused, " and "))
+		}
+
+		return config{}, errors.New("no method is cached, list them by -methods")
+	}
+
+	if cfg.TTL <= 0 {
+		return config{}, fmt.Errorf("ttl %s must be positive", cfg.TTL)
+	}
+
+	if cfg.Size < 1 {
+		return config{}, errors.New("size must be positive")
+	}
+
+	if _, err := cfg.methodTTLs(); err != nil {
+		return config{}, err
+	}
+
+	return cfg, nil
+}
+
+// methodTTLs returns time to live of cached results by method name.
+func (cfg config) methodTTLs() (map[string]time.Duration, error) {
+	ttls := make(map[string]time.Duration, len(cfg.Methods))
+
+	for _, m := range cfg.Methods {
+		name, value, ok := strings.Cut(m, ":")
+		if !ok {
+			ttls[name] = cfg.TTL
+			continue
+		}
+
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("method %q: %w", name, err)
+		}
+
+		if ttl <= 0 {
+			return nil, fmt.Errorf("method %q: ttl %s must be positive", name, ttl)
+		}
+
+		ttls[name] = ttl
+	}
+
+	return ttls, nil
+}
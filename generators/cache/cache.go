@@ -0,0 +1,290 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/gen/analysis"
+)
+
+func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]gen.File, error) {
+	return gen.GenerateFiles(ctx, name, gp, func(buf *bytes.Buffer, gp []gen.Please) error {
+		return generate(buf, name, gp)
+	})
+}
+
+type methInfo struct {
+	analysis.DecoratedMethod
+	// Cached is true if results of the method are cached.
+	Cached bool
+	TTL    string
+	// KeyFields declares fields of the key struct, KeyLit initializes them with the arguments.
+	KeyFields string
+	KeyLit    string
+	// KeyParams are the parameters of Invalidate method.
+	KeyParams string
+	// ValueType is the struct type of cached results, ValueLit initializes it with the results.
+	ValueType string
+	ValueLit  string
+	// Hit is the list of returned values on cache hit.
+	Hit string
+}
+
+func generate(buf *bytes.Buffer, name gen.GeneratorName, gp []gen.Please) error {
+	imports := gen.NewImports(gp[0].TS.Pkg.Types, gp[0].Imports)
+	imports.Reserve(
+		"cacheLRU", "cacheEntry", "newCacheLRU", "K", "V", "c", "size", "ttl", "key", "value", "ok",
+		"elem", "entry", "expires", "oldest", "w", "v", "cacheKey", "cached",
+	)
+
+	ifaces, err := analysis.InterfacesOf(gp, imports, "w", "cacheKey", "cached")
+	if err != nil {
+		return fmt.Errorf("analyze: %w", err)
+	}
+
+	if err := gen.CheckShared(name, gp, "cacheLRU", "cacheEntry", "newCacheLRU"); err != nil {
+		return err
+	}
+
+	body := bytes.NewBuffer(nil)
+
+	if !gen.SharedDeclared(name, gp, nil) {
+		if err := imports.Execute(body, tmplLRU, nil); err != nil {
+			return fmt.Errorf("execute template: %w", err)
+		}
+	}
+
+	for i, pls := range gp {
+		cfg, err := parseArgs(pls.Args, config{
+			TTL:  time.Minute,
+			Size: 1024,
+		})
+		if err != nil {
+			return fmt.Errorf("parse command arguments: %w", err)
+		}
+
+		if cfg.Sorted {
+			ifaces[i].SortMethods()
+		}
+
+		methInfos, err := analyze(ifaces[i], imports, cfg)
+		if err != nil {
+			return fmt.Errorf("analyze: %w", err)
+		}
+
+		if err := genCache(body, imports, ifaces[i], methInfos, cfg); err != nil {
+			return err
+		}
+	}
+
+	imports.Write(buf)
+	buf.Write(body.Bytes())
+
+	return nil
+}
+
+func analyze(iface analysis.Interface, imports *gen.Imports, cfg config) ([]methInfo, error) {
+	qf := imports.Qualifier
+
+	ttls, err := cfg.methodTTLs()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", iface.Pos, err)
+	}
+
+	if err := iface.CheckMethods(slices.Sorted(maps.Keys(ttls))...); err != nil {
+		return nil, err
+	}
+
+	methInfos := make([]methInfo, 0, len(iface.Methods))
+
+	for _, meth := range iface.Methods {
+		minf := methInfo{
+			DecoratedMethod: analysis.DecoratedMethodOf(meth, qf),
+		}
+
+		ttl, listed := ttls[meth.Name]
+
+		keyFields, keyLit, keyParams, okKey := extractKey(meth, qf)
+		valueType, valueLit, hit, okValue := extractValue(meth, meth.ResultNames(), minf.Err, qf)
+
+		switch {
+		case !listed:
+		case !okKey:
+			return nil, fmt.Errorf("%s: method %q can't be cached: parameters must be strictly comparable",
+				iface.Pos, meth.Name)
+		case !okValue:
+			return nil, fmt.Errorf("%s: results of method %q can't be cached", iface.Pos, meth.Name)
+		default:
+			if err := iface.CheckNotMethods("Invalidate"+meth.Name, "cache"+meth.Name); err != nil {
+				return nil, err
+			}
+
+			minf.Cached = true
+			minf.TTL = imports.Render(gen.Duration(ttl))
+			minf.KeyFields = keyFields
+			minf.KeyLit = keyLit
+			minf.KeyParams = keyParams
+			minf.ValueType = valueType
+			minf.ValueLit = valueLit
+			minf.Hit = hit
+		}
+
+		methInfos = append(methInfos, minf)
+	}
+
+	return methInfos, nil
+}
+
+// extractKey returns fields and literal of the key struct of the method's parameters
+// excluding the leading context. Ok is false if any of the parameters is not strictly comparable.
+func extractKey(meth analysis.Method, qf types.Qualifier) (fields, lit, params string, ok bool) {
+	var fieldList, litList, paramList []string
+
+	keyParams := meth.Params
+	if meth.Ctx() != "" {
+		keyParams = keyParams[1:]
+	}
+
+	for _, param := range keyParams {
+		if !strictlyComparable(param.Type) {
+			return "", "", "", false
+		}
+
+		decl := param.Name + " " + types.TypeString(param.Type, qf)
+
+		fieldList = append(fieldList, decl)
+		litList = append(litList, param.Name+": "+param.Name)
+		paramList = append(paramList, decl)
+	}
+
+	return strings.Join(fieldList, "\n\t"), strings.Join(litList, ", "), strings.Join(paramList, ", "), true
+}
+
+// strictlyComparable reports whether comparing the values of the type never panics.
+// Interfaces are not strictly comparable, their dynamic values may be not comparable.
+// Type parameters are strictly comparable if all types of their type sets are.
+func strictlyComparable(typ types.Type) bool {
+	if tparam, ok := types.Unalias(typ).(*types.TypeParam); ok {
+		return strictlyComparableTerms(tparam.Constraint().Underlying().(*types.Interface))
+	}
+
+	if !types.Comparable(typ) {
+		return false
+	}
+
+	switch typ := typ.Underlying().(type) {
+	case *types.Interface:
+		return false
+	case *types.Array:
+		return strictlyComparable(typ.Elem())
+	case *types.Struct:
+		for i := range typ.NumFields() {
+			if !strictlyComparable(typ.Field(i).Type()) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// strictlyComparableTerms reports whether the constraint restricts the type set by the union of
+// strictly comparable types.
+func strictlyComparableTerms(constraint *types.Interface) bool {
+	restricted := false
+
+	for i := range constraint.NumEmbeddeds() {
+		switch embedded := constraint.EmbeddedType(i).(type) {
+		case *types.Union:
+			for j := range embedded.Len() {
+				if !strictlyComparable(embedded.Term(j).Type()) {
+					return false
+				}
+			}
+
+			restricted = true
+		case *types.Interface:
+			if strictlyComparableTerms(embedded) {
+				restricted = true
+			}
+		default:
+			if embedded, ok := embedded.Underlying().(*types.Interface); ok {
+				if strictlyComparableTerms(embedded) {
+					restricted = true
+				}
+
+				continue
+			}
+
+			if !strictlyComparable(embedded) {
+				return false
+			}
+
+			restricted = true
+		}
+	}
+
+	return restricted
+}
+
+// extractValue returns type and literal of the struct of the method's results excluding the trailing error
+// and the list of values returned from it on cache hit. Ok is false if there are no such results.
+func extractValue(
+	meth analysis.Method,
+	names []string,
+	errName string,
+	qf types.Qualifier,
+) (typ, lit, hit string, ok bool) {
+	var fieldList, litList, hitList []string
+
+	for i, res := range meth.Results {
+		if names[i] == errName {
+			hitList = append(hitList, "nil")
+			continue
+		}
+
+		fieldList = append(fieldList, names[i]+" "+types.TypeString(res.Type, qf))
+		litList = append(litList, names[i])
+		hitList = append(hitList, "cached."+names[i])
+	}
+
+	if len(fieldList) == 0 {
+		return "", "", "", false
+	}
+
+	typ = "struct{ " + strings.Join(fieldList, "; ") + " }"
+
+	return typ, typ + "{" + strings.Join(litList, ", ") + "}", strings.Join(hitList, ", "), true
+}
+
+func genCache(
+	buf *bytes.Buffer, imports *gen.Imports, iface analysis.Interface, methInfos []methInfo, cfg config,
+) error {
+	data := struct {
+		analysis.Decorator
+		Methods   []methInfo
+		KeyPrefix string
+		Size      int
+		// Cached reports whether results of any method are cached.
+		Cached bool
+	}{
+		Decorator: analysis.DecoratorOf("Cache", iface, imports.Qualifier),
+		Methods:   methInfos,
+		KeyPrefix: analysis.ConcreteName("cache", iface.Name),
+		Size:      cfg.Size,
+		Cached:    slices.ContainsFunc(methInfos, func(m methInfo) bool { return m.Cached }),
+	}
+
+	if err := imports.Execute(buf, tmpl, data); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	return nil
+}
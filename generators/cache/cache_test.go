@@ -0,0 +1,35 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/internal/gentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const src = `package p
+
+import "context"
+
+type Getter interface {
+	Get(ctx context.Context, id string) (string, error)
+	InvalidateGet(ctx context.Context, id string) (bool, error)
+}
+`
+
+func TestGenerate_helperClash(t *testing.T) {
+	t.Parallel()
+
+	_, err := Generate(context.Background(), "cache", []gen.Please{gentest.Please(t, src, "Getter", "-methods=Get")})
+	assert.ErrorContains(t, err, "generated InvalidateGet clashes with the method of the interface")
+
+	// The helper is generated for the cached methods only.
+	files, err := Generate(context.Background(), "cache", []gen.Please{
+		gentest.Please(t, src, "Getter", "-methods=InvalidateGet"),
+	})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
@@ -0,0 +1,158 @@
+package cache
+
+import (
+	"text/template"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+const tmplLRUText = `// cacheLRU is a least recently used cache which entries expire after ttl.
+type cacheLRU[K comparable, V any] struct {
+	mu      {{pkg "sync"}}.Mutex
+	size    int
+	ttl     {{pkg "time"}}.Duration
+	order   *{{pkg "container/list"}}.List
+	entries map[K]*{{pkg "container/list"}}.Element
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires {{pkg "time"}}.Time
+}
+
+func newCacheLRU[K comparable, V any](size int, ttl {{pkg "time"}}.Duration) *cacheLRU[K, V] {
+	return &cacheLRU[K, V]{
+		size:    size,
+		ttl:     ttl,
+		order:   {{pkg "container/list"}}.New(),
+		entries: make(map[K]*{{pkg "container/list"}}.Element, size),
+	}
+}
+
+func (c *cacheLRU[K, V]) get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return value, false
+	}
+
+	entry := elem.Value.(*cacheEntry[K, V])
+
+	if {{pkg "time"}}.Now().After(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+
+		return value, false
+	}
+
+	c.order.MoveToFront(elem)
+
+	return entry.value, true
+}
+
+func (c *cacheLRU[K, V]) put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := {{pkg "time"}}.Now().Add(c.ttl)
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry[K, V])
+		entry.value = value
+		entry.expires = expires
+
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry[K, V]{key: key, value: value, expires: expires})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry[K, V]).key)
+	}
+}
+
+func (c *cacheLRU[K, V]) remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+`
+
+//nolint:lll
+const tmplText = `{{range .Methods}}{{if .Cached}}// {{$.KeyPrefix}}{{.Name}}Key is the key of cached results of {{$.InterfaceName}}.{{.Name}}.
+type {{$.KeyPrefix}}{{.Name}}Key{{$.TypeParamsDecl}} struct {
+{{- if .KeyFields}}
+	{{.KeyFields}}
+{{- end}}
+}
+
+{{end}}{{end -}}
+// *{{.ConcrName}} implements {{.InterfaceName}}.
+type {{.ConcrName}}{{.TypeParamsDecl}} struct {
+	v {{.InterfaceName}}{{.TypeParams}}
+{{- if .Cached}}
+{{range .Methods}}{{if .Cached}}
+	cache{{.Name}} *cacheLRU[{{$.KeyPrefix}}{{.Name}}Key{{$.TypeParams}}, {{.ValueType}}]
+{{- end}}{{end}}
+{{- end}}
+}
+
+// New{{.ConcrName}} returns a new *{{.ConcrName}} caching results of v.
+func New{{.ConcrName}}{{.TypeParamsDecl}}(v {{.InterfaceName}}{{.TypeParams}}) (*{{.ConcrName}}{{.TypeParams}}, error) {
+	if v == nil {
+		return nil, {{pkg "errors"}}.New("v is nil")
+	}
+
+	return &{{.ConcrName}}{{.TypeParams}}{
+		v: v,
+{{- range .Methods}}{{if .Cached}}
+		cache{{.Name}}: newCacheLRU[{{$.KeyPrefix}}{{.Name}}Key{{$.TypeParams}}, {{.ValueType}}]({{$.Size}}, {{.TTL}}),
+{{- end}}{{end}}
+	}, nil
+}
+{{range .Methods}}
+{{.Doc}}func (w *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+{{- if .Cached}}
+	cacheKey := {{$.KeyPrefix}}{{.Name}}Key{{$.TypeParams}}{{"{"}}{{.KeyLit}}}
+	if cached, ok := w.cache{{.Name}}.get(cacheKey); ok {
+		return {{.Hit}}
+	}
+
+	{{.Results}} = w.v.{{.Name}}({{.Args}})
+{{- if .Err}}
+	if {{.Err}} == nil {
+		w.cache{{.Name}}.put(cacheKey, {{.ValueLit}})
+	}
+{{- else}}
+	w.cache{{.Name}}.put(cacheKey, {{.ValueLit}})
+{{- end}}
+
+	return {{.Results}}
+}
+
+// Invalidate{{.Name}} removes the cached results of {{.Name}} called with the arguments.
+func (w *{{$.ConcrName}}{{$.TypeParams}}) Invalidate{{.Name}}({{.KeyParams}}) {
+	w.cache{{.Name}}.remove({{$.KeyPrefix}}{{.Name}}Key{{$.TypeParams}}{{"{"}}{{.KeyLit}}})
+{{- else}}
+	{{if .Ret}}return {{end}}w.v.{{.Name}}({{.Args}})
+{{- end}}
+}
+{{end}}
+`
+
+var (
+	tmpl    = template.Must(template.New("cache").Funcs(gen.TemplateFuncs).Parse(tmplText))
+	tmplLRU = template.Must(template.New("lru").Funcs(gen.TemplateFuncs).Parse(tmplLRUText))
+)
@@ -0,0 +1,53 @@
+package fake
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+type config struct {
+	Get    string
+	Put    string
+	List   string
+	Delete string
+	Sorted bool
+}
+
+func parseArgs(arguments []string, defaultValue config) (config, error) {
+	var cfg config
+
+	flagset := flag.NewFlagSet("", flag.ContinueOnError)
+
+	flagset.StringVar(&cfg.Get, "get", defaultValue.Get, "name of the Get(ctx context.Context, key K) (V, error) method")
+	flagset.StringVar(&cfg.Put, "put", defaultValue.Put, "name of the Put(ctx context.Context, v V) error method")
+	flagset.StringVar(&cfg.List, "list", defaultValue.List, "name of the List(ctx context.Context) ([]V, error) method")
+	flagset.StringVar(&cfg.Delete, "delete", defaultValue.Delete,
+		"name of the Delete(ctx context.Context, key K) error method")
+	flagset.BoolVar(&cfg.Sorted, "sorted", defaultValue.Sorted, gen.SortedUsage)
+
+	if err := flagset.Parse(arguments); err != nil {
+		return config{}, fmt.Errorf("flagset: Parse: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// explicit returns the methods names which differ from the default ones.
+func (cfg config) explicit(defaultValue config) []string {
+	var names []string
+
+	for _, pair := range [][2]string{
+		{cfg.Get, defaultValue.Get},
+		{cfg.Put, defaultValue.Put},
+		{cfg.List, defaultValue.List},
+		{cfg.Delete, defaultValue.Delete},
+	} {
+		if pair[0] != pair[1] {
+			names = append(names, pair[0])
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,255 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/types"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/gen/analysis"
+)
+
+func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]gen.File, error) {
+	return gen.GenerateFiles(ctx, name, gp, generate)
+}
+
+// methKind is a kind of the repository method recognized by its signature.
+type methKind int
+
+const (
+	kindUnknown methKind = iota
+	kindGet              // Get(ctx, key) (V, error)
+	kindPut              // Put(ctx, v) error
+	kindList             // List(ctx) ([]V, error)
+	kindDelete           // Delete(ctx, key) error
+)
+
+type methInfo struct {
+	Name string
+	Sig  string
+	Kind methKind
+	// Doc is the method doc as the comment lines.
+	Doc string
+
+	// Ctx is the context parameter name of the classified method.
+	Ctx string
+	// Arg is the key or the value parameter name of the classified method.
+	Arg string
+
+	// key and entity are types of the classified method's argument or result.
+	key    types.Type
+	entity types.Type
+}
+
+func (m methInfo) IsGet() bool    { return m.Kind == kindGet }
+func (m methInfo) IsPut() bool    { return m.Kind == kindPut }
+func (m methInfo) IsList() bool   { return m.Kind == kindList }
+func (m methInfo) IsDelete() bool { return m.Kind == kindDelete }
+
+type ifaceInfo struct {
+	name           string
+	methInfos      []methInfo
+	typeParamsDecl string
+	typeParams     string
+	key            string
+	entity         string
+}
+
+var defaultConfig = config{
+	Get:    "Get",
+	Put:    "Put",
+	List:   "List",
+	Delete: "Delete",
+}
+
+func generate(buf *bytes.Buffer, gp []gen.Please) error {
+	imports := gen.NewImports(gp[0].TS.Pkg.Types, gp[0].Imports)
+	ifaces, err := analysis.InterfacesOf(gp, imports, "f", "v", "k", "items", "zero", "ok", "i", "err")
+	if err != nil {
+		return fmt.Errorf("analyze: %w", err)
+	}
+
+	body := bytes.NewBuffer(nil)
+
+	for i, pls := range gp {
+		cfg, err := parseArgs(pls.Args, defaultConfig)
+		if err != nil {
+			return fmt.Errorf("parse command arguments: %w", err)
+		}
+
+		iface := ifaces[i]
+		if cfg.Sorted {
+			iface.SortMethods()
+		}
+
+		if err := iface.CheckMethods(cfg.explicit(defaultConfig)...); err != nil {
+			return err
+		}
+
+		info, err := analyze(iface, imports, cfg)
+		if err != nil {
+			return fmt.Errorf("analyze: %w", err)
+		}
+
+		if err := genFake(body, imports, info); err != nil {
+			return err
+		}
+	}
+
+	imports.Write(buf)
+	buf.Write(body.Bytes())
+
+	return nil
+}
+
+func analyze(iface analysis.Interface, imports *gen.Imports, cfg config) (ifaceInfo, error) {
+	qf := imports.Qualifier
+
+	methInfos := make([]methInfo, 0, len(iface.Methods))
+
+	for _, meth := range iface.Methods {
+		minf := classify(meth, cfg)
+		minf.Sig = meth.Sig(qf)
+		minf.Doc = meth.DocComment()
+
+		methInfos = append(methInfos, minf)
+	}
+
+	key, entity := unify(methInfos)
+
+	// The items are stored by the key, so the fake can't store anything without both types.
+	switch {
+	case key == nil:
+		return ifaceInfo{}, fmt.Errorf("%s: interface %s has no key method %s(ctx context.Context, key K) (V, error) "+
+			"or %s(ctx context.Context, key K) error", iface.Pos, iface.Name, cfg.Get, cfg.Delete)
+	case entity == nil:
+		return ifaceInfo{}, fmt.Errorf("%s: interface %s has no item method %s(ctx context.Context, key K) (V, error), "+
+			"%s(ctx context.Context, v V) error or %s(ctx context.Context) ([]V, error)",
+			iface.Pos, iface.Name, cfg.Get, cfg.Put, cfg.List)
+	}
+
+	return ifaceInfo{
+		name:           iface.Name,
+		methInfos:      methInfos,
+		typeParamsDecl: analysis.TypeParamsDecl(iface.TypeParams, qf),
+		typeParams:     analysis.TypeArgs(iface.TypeParams),
+		key:            types.TypeString(key, qf),
+		entity:         types.TypeString(entity, qf),
+	}, nil
+}
+
+// classify recognizes the repository method by its name mapped by the config and its exact signature.
+// Methods with other signatures are unknown.
+func classify(meth analysis.Method, cfg config) methInfo {
+	minf := methInfo{Name: meth.Name}
+
+	params := meth.Params
+	results := meth.Results
+
+	if meth.Ctx() == "" || meth.Variadic {
+		return minf
+	}
+
+	switch meth.Name {
+	case cfg.Get:
+		if len(params) == 2 && len(results) == 2 && analysis.IsError(results[1].Type) {
+			minf.Kind = kindGet
+			minf.key = params[1].Type
+			minf.entity = results[0].Type
+		}
+
+	case cfg.Put:
+		if len(params) == 2 && len(results) == 1 && analysis.IsError(results[0].Type) {
+			minf.Kind = kindPut
+			minf.entity = params[1].Type
+		}
+
+	case cfg.List:
+		if len(params) == 1 && len(results) == 2 && analysis.IsError(results[1].Type) {
+			if slice, ok := results[0].Type.(*types.Slice); ok {
+				minf.Kind = kindList
+				minf.entity = slice.Elem()
+			}
+		}
+
+	case cfg.Delete:
+		if len(params) == 2 && len(results) == 1 && analysis.IsError(results[0].Type) {
+			minf.Kind = kindDelete
+			minf.key = params[1].Type
+		}
+	}
+
+	if minf.key != nil && !types.Comparable(minf.key) {
+		minf.Kind = kindUnknown
+	}
+
+	if minf.Kind != kindUnknown {
+		minf.Ctx = params[0].Name
+
+		if len(params) == 2 {
+			minf.Arg = params[1].Name
+		}
+	}
+
+	return minf
+}
+
+// unify selects the key and entity types stored by the fake.
+// Classified methods which disagree with the selected types become unknown.
+func unify(methInfos []methInfo) (key types.Type, entity types.Type) {
+	for _, minf := range methInfos {
+		if key == nil && minf.key != nil {
+			key = minf.key
+		}
+
+		if entity == nil && minf.entity != nil {
+			entity = minf.entity
+		}
+	}
+
+	for i := range methInfos {
+		minf := &methInfos[i]
+
+		switch {
+		case minf.Kind == kindUnknown:
+			continue
+		case key == nil || entity == nil:
+			minf.Kind = kindUnknown
+		case minf.key != nil && !types.Identical(minf.key, key):
+			minf.Kind = kindUnknown
+		case minf.entity != nil && !types.Identical(minf.entity, entity):
+			minf.Kind = kindUnknown
+		}
+	}
+
+	return key, entity
+}
+
+func genFake(buf *bytes.Buffer, imports *gen.Imports, inf ifaceInfo) error {
+	concrname := analysis.ConcreteName("Fake", inf.name)
+
+	data := struct {
+		ConcrName      string
+		InterfaceName  string
+		TypeParamsDecl string
+		TypeParams     string
+		Key            string
+		Entity         string
+		Methods        []methInfo
+	}{
+		ConcrName:      concrname,
+		InterfaceName:  inf.name,
+		TypeParamsDecl: inf.typeParamsDecl,
+		TypeParams:     inf.typeParams,
+		Key:            inf.key,
+		Entity:         inf.entity,
+		Methods:        inf.methInfos,
+	}
+
+	if err := imports.Execute(buf, tmpl, data); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/internal/gentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const src = `package p
+
+import "context"
+
+type Item struct{ ID string }
+
+type Lister interface {
+	List(ctx context.Context) ([]Item, error)
+}
+
+type Deleter interface {
+	Delete(ctx context.Context, id string) error
+}
+
+type Renamed interface {
+	Find(ctx context.Context, id string) (Item, error)
+}
+`
+
+func TestGenerate_noKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := Generate(context.Background(), "fake", []gen.Please{gentest.Please(t, src, "Lister")})
+	assert.ErrorContains(t, err, "interface Lister has no key method Get(ctx context.Context, key K) (V, error) "+
+		"or Delete(ctx context.Context, key K) error")
+}
+
+func TestGenerate_noItem(t *testing.T) {
+	t.Parallel()
+
+	_, err := Generate(context.Background(), "fake", []gen.Please{gentest.Please(t, src, "Deleter")})
+	assert.ErrorContains(t, err, "interface Deleter has no item method Get(ctx context.Context, key K) (V, error), "+
+		"Put(ctx context.Context, v V) error or List(ctx context.Context) ([]V, error)")
+}
+
+func TestGenerate_mappedKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := Generate(context.Background(), "fake", []gen.Please{gentest.Please(t, src, "Renamed")})
+	require.ErrorContains(t, err, "interface Renamed has no key method Get")
+
+	files, err := Generate(context.Background(), "fake", []gen.Please{gentest.Please(t, src, "Renamed", "-get=Find")})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	assert.Contains(t, string(files[0].Data), "items map[string]Item")
+}
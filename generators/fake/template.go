@@ -0,0 +1,122 @@
+package fake
+
+import (
+	"text/template"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+//nolint:lll
+const tmplText = `// Err{{.ConcrName}}NotFound is returned by *{{.ConcrName}} if the item is not stored.
+var Err{{.ConcrName}}NotFound = {{pkg "errors"}}.New("{{.ConcrName}}: not found")
+
+// *{{.ConcrName}} implements {{.InterfaceName}}.
+type {{.ConcrName}}{{.TypeParamsDecl}} struct {
+	mu    {{pkg "sync"}}.RWMutex
+	items map[{{.Key}}]{{.Entity}}
+	keys  []{{.Key}}
+	key   func({{.Entity}}) {{.Key}}
+}
+
+// New{{.ConcrName}} returns a new empty *{{.ConcrName}}.
+// The key function returns the key of the stored item.
+func New{{.ConcrName}}{{.TypeParamsDecl}}(key func({{.Entity}}) {{.Key}}) (*{{.ConcrName}}{{.TypeParams}}, error) {
+	if key == nil {
+		return nil, {{pkg "errors"}}.New("key is nil")
+	}
+	return &{{.ConcrName}}{{.TypeParams}}{
+		items: map[{{.Key}}]{{.Entity}}{},
+		key:   key,
+	}, nil
+}
+{{range .Methods}}
+{{- if .IsGet}}
+{{.Doc}}func (f *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+	if err := {{.Ctx}}.Err(); err != nil {
+		var zero {{$.Entity}}
+		return zero, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	v, ok := f.items[{{.Arg}}]
+	if !ok {
+		return v, Err{{$.ConcrName}}NotFound
+	}
+
+	return v, nil
+}
+{{else if .IsPut}}
+{{.Doc}}func (f *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+	if err := {{.Ctx}}.Err(); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.items == nil {
+		f.items = map[{{$.Key}}]{{$.Entity}}{}
+	}
+
+	k := f.key({{.Arg}})
+
+	if _, ok := f.items[k]; !ok {
+		f.keys = append(f.keys, k)
+	}
+
+	f.items[k] = {{.Arg}}
+
+	return nil
+}
+{{else if .IsList}}
+{{.Doc}}func (f *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+	if err := {{.Ctx}}.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	items := make([]{{$.Entity}}, 0, len(f.keys))
+
+	for _, k := range f.keys {
+		items = append(items, f.items[k])
+	}
+
+	return items, nil
+}
+{{else if .IsDelete}}
+{{.Doc}}func (f *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+	if err := {{.Ctx}}.Err(); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.items[{{.Arg}}]; !ok {
+		return Err{{$.ConcrName}}NotFound
+	}
+
+	delete(f.items, {{.Arg}})
+
+	for i, k := range f.keys {
+		if k == {{.Arg}} {
+			f.keys = append(f.keys[:i], f.keys[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+{{else}}
+{{.Doc}}func (*{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+	panic("method {{.Name}} is not implemented!")
+}
+{{end}}
+{{- end}}
+`
+
+var tmpl = template.Must(template.New("fake").Funcs(gen.TemplateFuncs).Parse(tmplText))
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+type config struct {
+	Expvar bool
+	Sorted bool
+}
+
+func parseArgs(arguments []string, defaultValue config) (config, error) {
+	var cfg config
+
+	flagset := flag.NewFlagSet("", flag.ContinueOnError)
+
+	flagset.BoolVar(&cfg.Expvar, "expvar", defaultValue.Expvar, "generate the expvar based recorder")
+	flagset.BoolVar(&cfg.Sorted, "sorted", defaultValue.Sorted, gen.SortedUsage)
+
+	if err := flagset.Parse(arguments); err != nil {
+		return config{}, fmt.Errorf("flagset: Parse: %w", err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/gen/analysis"
+)
+
+func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]gen.File, error) {
+	return gen.GenerateFiles(ctx, name, gp, func(buf *bytes.Buffer, gp []gen.Please) error {
+		return generate(buf, name, gp)
+	})
+}
+
+func generate(buf *bytes.Buffer, name gen.GeneratorName, gp []gen.Please) error {
+	imports := gen.NewImports(gp[0].TS.Pkg.Types, gp[0].Imports)
+	imports.Reserve(
+		"MetricsRecorder", "expvarBuckets", "ExpvarRecorder", "NewExpvarRecorder",
+		"name", "r", "iface", "method", "d", "err", "key", "histogram", "bound", "ok",
+		"w", "v", "recorder", "callStart",
+	)
+
+	ifaces, err := analysis.InterfacesOf(gp, imports, "w", "callStart")
+	if err != nil {
+		return fmt.Errorf("analyze: %w", err)
+	}
+
+	expvar := false
+
+	for i, pls := range gp {
+		cfg, err := parseArgs(pls.Args, config{})
+		if err != nil {
+			return fmt.Errorf("parse command arguments: %w", err)
+		}
+
+		if cfg.Sorted {
+			ifaces[i].SortMethods()
+		}
+
+		if cfg.Expvar {
+			expvar = true
+		}
+	}
+
+	if err := gen.CheckShared(name, gp, "MetricsRecorder"); err != nil {
+		return err
+	}
+
+	body := bytes.NewBuffer(nil)
+
+	if !gen.SharedDeclared(name, gp, nil) {
+		if err := imports.Execute(body, tmplRecorder, nil); err != nil {
+			return fmt.Errorf("execute template: %w", err)
+		}
+	}
+
+	if expvar {
+		if err := gen.CheckShared(name, gp, "expvarBuckets", "ExpvarRecorder", "NewExpvarRecorder"); err != nil {
+			return err
+		}
+
+		if !gen.SharedDeclared(name, gp, expvarArg) {
+			if err := imports.Execute(body, tmplExpvar, nil); err != nil {
+				return fmt.Errorf("execute template: %w", err)
+			}
+		}
+	}
+
+	for _, iface := range ifaces {
+		if err := genMetrics(body, imports, iface); err != nil {
+			return err
+		}
+	}
+
+	imports.Write(buf)
+	buf.Write(body.Bytes())
+
+	return nil
+}
+
+// expvarArg reports whether the metrics directive's arguments enable the expvar recorder.
+func expvarArg(args []string) bool {
+	cfg, err := parseArgs(args, config{})
+
+	return err == nil && cfg.Expvar
+}
+
+func genMetrics(buf *bytes.Buffer, imports *gen.Imports, iface analysis.Interface) error {
+	data := struct {
+		analysis.Decorator
+		Methods []analysis.DecoratedMethod
+	}{
+		Decorator: analysis.DecoratorOf("Metrics", iface, imports.Qualifier),
+		Methods:   analysis.DecoratedMethodsOf(iface, imports.Qualifier),
+	}
+
+	if err := imports.Execute(buf, tmpl, data); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package metrics
+
+import (
+	"text/template"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+const tmplRecorderText = `// MetricsRecorder records calls of the methods wrapped by the metrics wrappers.
+type MetricsRecorder interface {
+	// RecordCall records a call of the interface's method which lasted d and returned err.
+	// Err is always nil for methods without error result.
+	RecordCall(iface, method string, d {{pkg "time"}}.Duration, err error)
+}
+
+`
+
+const tmplExpvarText = `// expvarBuckets are the upper bounds of the latency histograms buckets.
+var expvarBuckets = []{{pkg "time"}}.Duration{
+	{{pkg "time"}}.Millisecond,
+	5 * {{pkg "time"}}.Millisecond,
+	10 * {{pkg "time"}}.Millisecond,
+	50 * {{pkg "time"}}.Millisecond,
+	100 * {{pkg "time"}}.Millisecond,
+	500 * {{pkg "time"}}.Millisecond,
+	{{pkg "time"}}.Second,
+	5 * {{pkg "time"}}.Second,
+	10 * {{pkg "time"}}.Second,
+}
+
+// ExpvarRecorder is a MetricsRecorder publishing metrics with expvar.
+//
+// Calls and errors are counted by the "<iface>.<method>" keys.
+// Latency histograms count calls lasting less or equal to the buckets bounds,
+// the total count and sum of durations in seconds.
+type ExpvarRecorder struct {
+	mu      {{pkg "sync"}}.Mutex
+	calls   *{{pkg "expvar"}}.Map
+	errors  *{{pkg "expvar"}}.Map
+	latency *{{pkg "expvar"}}.Map
+}
+
+// NewExpvarRecorder returns a new *ExpvarRecorder publishing the name_calls, name_errors
+// and name_latency maps. It panics if the maps are already published.
+func NewExpvarRecorder(name string) *ExpvarRecorder {
+	return &ExpvarRecorder{
+		calls:   {{pkg "expvar"}}.NewMap(name + "_calls"),
+		errors:  {{pkg "expvar"}}.NewMap(name + "_errors"),
+		latency: {{pkg "expvar"}}.NewMap(name + "_latency"),
+	}
+}
+
+func (r *ExpvarRecorder) RecordCall(iface, method string, d {{pkg "time"}}.Duration, err error) {
+	key := iface + "." + method
+
+	r.calls.Add(key, 1)
+
+	if err != nil {
+		r.errors.Add(key, 1)
+	}
+
+	histogram := r.histogram(key)
+
+	for _, bound := range expvarBuckets {
+		if d <= bound {
+			histogram.Add("le_"+bound.String(), 1)
+		}
+	}
+
+	histogram.Add("count", 1)
+	histogram.AddFloat("sum", d.Seconds())
+}
+
+func (r *ExpvarRecorder) histogram(key string) *{{pkg "expvar"}}.Map {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if histogram, ok := r.latency.Get(key).(*{{pkg "expvar"}}.Map); ok {
+		return histogram
+	}
+
+	histogram := new({{pkg "expvar"}}.Map)
+	r.latency.Set(key, histogram)
+
+	return histogram
+}
+
+`
+
+const tmplText = `// *{{.ConcrName}} implements {{.InterfaceName}}.
+type {{.ConcrName}}{{.TypeParamsDecl}} struct {
+	v        {{.InterfaceName}}{{.TypeParams}}
+	recorder MetricsRecorder
+}
+
+// New{{.ConcrName}} returns a new *{{.ConcrName}} recording calls of v with recorder.
+func New{{.ConcrName}}{{.TypeParamsDecl}}(v {{.InterfaceName}}{{.TypeParams}}, recorder MetricsRecorder)
+{{- " "}}(*{{.ConcrName}}{{.TypeParams}}, error) {
+	if v == nil {
+		return nil, {{pkg "errors"}}.New("v is nil")
+	}
+
+	if recorder == nil {
+		return nil, {{pkg "errors"}}.New("recorder is nil")
+	}
+
+	return &{{.ConcrName}}{{.TypeParams}}{
+		v:        v,
+		recorder: recorder,
+	}, nil
+}
+{{range .Methods}}
+{{.Doc}}func (w *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+	callStart := {{pkg "time"}}.Now()
+
+	{{if .Ret}}{{.Results}} = {{end}}w.v.{{.Name}}({{.Args}})
+	w.recorder.RecordCall("{{$.InterfaceName}}", "{{.Name}}", {{pkg "time"}}.Since(callStart), {{or .Err "nil"}})
+{{- if .Ret}}
+
+	return {{.Results}}
+{{- end}}
+}
+{{end}}
+`
+
+var (
+	tmpl         = template.Must(template.New("metrics").Funcs(gen.TemplateFuncs).Parse(tmplText))
+	tmplRecorder = template.Must(template.New("recorder").Funcs(gen.TemplateFuncs).Parse(tmplRecorderText))
+	tmplExpvar   = template.Must(template.New("expvar").Funcs(gen.TemplateFuncs).Parse(tmplExpvarText))
+)
@@ -1,194 +1,117 @@
 package mock
 
 import (
-	"fmt"
-	"go/ast"
+	"go/token"
 	"go/types"
-	"strconv"
 	"strings"
 
 	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/gen/analysis"
 )
 
 type methInfo struct {
-	Name    string
-	Sig     string
-	Args    string
-	ArgsSig string
-	Results string
-	Ret     bool
+	Name string
+	// Doc is the method doc as the comment lines.
+	Doc string
+	Sig string
+	// Args is the list of parameters names.
+	Args string
+	// CallArgs is the list of arguments passing the parameters through.
+	CallArgs      string
+	ArgsSig       string
+	ArgNames      []string
+	Results       string
+	ResultsSig    string
+	ResultsParams string
+	ResultNames   []string
+	Ret           bool
 }
 
 type ifaceInfo struct {
 	name           string
-	object         types.Object
+	qualName       string
+	pkg            *types.Package
+	pos            token.Position
 	methInfos      []methInfo
 	typeParamsDecl string
 	typeParams     string
 }
 
-func analyze(pls gen.Please, usedImports map[gen.PkgPath]gen.PkgName) (ifaceInfo, error) {
-	origIfacename := pls.TS.Spec.Name.Name
-	position := pls.TS.Pkg.Fset.Position(pls.TS.Spec.Pos())
+// analyze collects the interface info.
+// Types are qualified by the imports, so the types declared at the interface's package
+// are qualified too if the mock is not generated into the same package.
+func analyze(iface analysis.Interface, imports *gen.Imports) (ifaceInfo, error) {
+	qf := imports.Qualifier
 
-	var spec *ast.TypeSpec
+	methInfos := make([]methInfo, 0, len(iface.Methods))
 
-	// Handle aliased interface
-	if ident, ok := pls.TS.Spec.Type.(*ast.Ident); ok {
-		typeSpec, okTypeSpec := ident.Obj.Decl.(*ast.TypeSpec)
-		if !okTypeSpec {
-			return ifaceInfo{}, fmt.Errorf("%s: type %q expected to be an interface alias", position, origIfacename)
-		}
-
-		spec = typeSpec
-	} else {
-		spec = pls.TS.Spec
-	}
-
-	_, ok := spec.Type.(*ast.InterfaceType)
-	if !ok {
-		return ifaceInfo{}, fmt.Errorf("%s: type %q must be an interface", position, origIfacename)
-	}
-
-	ifacename := spec.Name.Name
-
-	object := pls.TS.Pkg.Types.Scope().Lookup(ifacename)
-	if object == nil {
-		return ifaceInfo{}, fmt.Errorf("%s: object %s not found", position, ifacename)
-	}
-
-	if _, ok := object.(*types.TypeName); !ok {
-		return ifaceInfo{}, fmt.Errorf("%v is not a named type", object)
-	}
-
-	objtyp := object.Type()
-
-	typ, ok := objtyp.(*types.Named)
-	if !ok {
-		return ifaceInfo{}, fmt.Errorf("unexpected type %T", objtyp)
-	}
-
-	pkgAliasFn := alias(pls.TS.Pkg.Types, pls.Imports, usedImports)
-
-	typeParamsDecl, typeParams := typeParams(typ, pkgAliasFn)
-
-	mset := types.NewMethodSet(objtyp)
-
-	methInfos := make([]methInfo, 0, mset.Len())
-
-	for i := range mset.Len() {
-		meth := mset.At(i).Obj()
+	for _, meth := range iface.Methods {
+		results := meth.IndexedResultNames()
 
-		sig := types.TypeString(meth.Type(), pkgAliasFn)
-		sig = strings.TrimPrefix(sig, "func")
-
-		argsSig, _, _ := strings.Cut(sig, ")")
-		argsSig = strings.Trim(argsSig, "()")
-		argsSig = strings.ReplaceAll(argsSig, ", ", "\n\t\t\t")
-		argsSig = "\n\t\t\t" + argsSig
-
-		sigtyp, ok := meth.Type().(*types.Signature)
-		if !ok {
-			return ifaceInfo{}, fmt.Errorf("unexpected type %T", meth.Type())
+		if len(results) > 0 {
+			if err := iface.CheckNotMethods(meth.Name+"Returns", meth.Name+"ReturnsOnCall", "queued"+meth.Name); err != nil {
+				return ifaceInfo{}, err
+			}
 		}
 
-		ret := sigtyp.Results().Len() > 0
-
 		methInfos = append(methInfos, methInfo{
-			Name:    meth.Name(),
-			Sig:     sig,
-			Args:    extractArgs(sigtyp),
-			ArgsSig: argsSig,
-			Results: extractResults(sigtyp),
-			Ret:     ret,
+			Name:          meth.Name,
+			Doc:           meth.DocComment(),
+			Sig:           meth.Sig(qf),
+			Args:          strings.Join(meth.ArgNames(), ", "),
+			CallArgs:      meth.Args(),
+			ArgsSig:       extractArgsSig(meth, qf),
+			ArgNames:      meth.ArgNames(),
+			Results:       strings.Join(results, ", "),
+			ResultsSig:    extractResultsSig(meth, qf),
+			ResultsParams: extractResultsParams(meth, qf),
+			ResultNames:   results,
+			Ret:           len(meth.Results) > 0,
 		})
 	}
 
 	return ifaceInfo{
-		name:           origIfacename,
-		object:         object,
+		name:           iface.Name,
+		pkg:            iface.Named.Obj().Pkg(),
+		pos:            iface.Pos,
 		methInfos:      methInfos,
-		typeParamsDecl: typeParamsDecl,
-		typeParams:     typeParams,
+		typeParamsDecl: analysis.TypeParamsDecl(iface.TypeParams, qf),
+		typeParams:     analysis.TypeArgs(iface.TypeParams),
 	}, nil
 }
 
-// extractArgs returns list of parameters names.
-func extractArgs(sig *types.Signature) string {
-	var args []string
-
-	params := sig.Params()
+// extractArgsSig returns list of parameters fields declarations.
+func extractArgsSig(meth analysis.Method, qf types.Qualifier) string {
+	var fields strings.Builder
 
-	for i := range params.Len() {
-		param := params.At(i)
-		args = append(args, param.Name())
+	for _, param := range meth.Params {
+		fields.WriteString("\n\t\t\t" + param.Name + " " + types.TypeString(param.Type, qf))
 	}
 
-	return strings.Join(args, ", ")
+	return fields.String()
 }
 
-// extractResults returns list of results names.
-func extractResults(sig *types.Signature) string {
-	var args []string
+// extractResultsSig returns list of results fields declarations.
+func extractResultsSig(meth analysis.Method, qf types.Qualifier) string {
+	var fields strings.Builder
 
-	results := sig.Results()
+	names := meth.IndexedResultNames()
 
-	for i := range results.Len() {
-		args = append(args, "r"+strconv.Itoa(i))
+	for i, res := range meth.Results {
+		fields.WriteString("\n\t\t\t" + names[i] + " " + types.TypeString(res.Type, qf))
 	}
 
-	return strings.Join(args, ", ")
+	return fields.String()
 }
 
-func alias(
-	pkg *types.Package,
-	imports map[gen.PkgPath]gen.PkgName,
-	usedImports map[gen.PkgPath]gen.PkgName,
-) types.Qualifier {
-	return func(p *types.Package) string {
-		if pkg == p {
-			// local imports are unqualified.
-			return ""
-		}
-
-		path := gen.PkgPath(p.Path())
-		alias := imports[path]
-
-		// Populate imports used by generated types.
-		// Include empty alias too.
-		if usedImports != nil {
-			usedImports[path] = alias
-		}
-
-		if alias == "" {
-			return p.Name()
-		}
-
-		return string(alias)
-	}
-}
-
-func typeParams(typ *types.Named, pkgAliasFn types.Qualifier) (typeParamsDecl string, typeParams string) {
-	if typ.TypeParams().Len() > 0 {
-		typeParamsDecl = "["
-		typeParams = "["
-
-		for i := range typ.TypeParams().Len() {
-			param := typ.TypeParams().At(i)
-			constraintName := types.TypeString(param.Constraint(), pkgAliasFn)
-
-			if i == 0 {
-				typeParamsDecl += param.String() + " " + constraintName
-				typeParams += param.String()
-			} else {
-				typeParamsDecl += ", " + param.String() + " " + constraintName
-				typeParams += ", " + param.String()
-			}
-		}
+// extractResultsParams returns list of results declared as parameters.
+func extractResultsParams(meth analysis.Method, qf types.Qualifier) string {
+	params := make([]string, len(meth.Results))
+	names := meth.IndexedResultNames()
 
-		typeParamsDecl += "]"
-		typeParams += "]"
+	for i, res := range meth.Results {
+		params[i] = names[i] + " " + types.TypeString(res.Type, qf)
 	}
 
-	return typeParamsDecl, typeParams
+	return strings.Join(params, ", ")
 }
@@ -5,13 +5,18 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+
+	"github.com/WinPooh32/genpls/gen"
 )
 
 type config struct {
-	Name string
-	Pkg  string
-	Dir  string
-	Test bool
+	Name    string
+	Pkg     string
+	Dir     string
+	Test    bool
+	Spy     bool
+	History bool
+	Sorted  bool
 }
 
 func (cfg *config) Filename() string {
@@ -45,6 +50,10 @@ func parseArgs(arguments []string, defaultValue config) (config, error) {
 	flagset.StringVar(&cfg.Pkg, "pkg", defaultValue.Pkg, "package name")
 	flagset.StringVar(&cfg.Dir, "dir", defaultValue.Dir, "package dir path")
 	flagset.BoolVar(&cfg.Test, "test", defaultValue.Test, "generate test package")
+	flagset.BoolVar(&cfg.Spy, "spy", defaultValue.Spy, "delegate calls of nil methods to the wrapped implementation")
+	flagset.BoolVar(&cfg.History, "history", defaultValue.History,
+		"record results, sequence numbers and timestamps of calls")
+	flagset.BoolVar(&cfg.Sorted, "sorted", defaultValue.Sorted, gen.SortedUsage)
 
 	if err := flagset.Parse(arguments); err != nil {
 		return config{}, fmt.Errorf("flagset: Parse: %w", err)
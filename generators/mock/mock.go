@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"maps"
+	"go/format"
+	"go/token"
+	"go/types"
 	"path/filepath"
 	"slices"
 	"strings"
 
 	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/gen/analysis"
 )
 
 func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]gen.File, error) {
@@ -19,8 +22,6 @@ func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]g
 
 	for _, pls := range gp {
 		buf.Reset()
-		buf.WriteString(gp[0].FormatDoNotEditHeader(name))
-		buf.WriteString(gp[0].FormatPkg())
 
 		cfg, err := parseArgs(pls.Args, config{
 			Name: strings.ToLower(pls.TS.Spec.Name.String()) + "_gen.go",
@@ -28,16 +29,34 @@ func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]g
 			Dir:  "mocks",
 		})
 		if err != nil {
-			return nil, fmt.Errorf("pasrse command arguments: %w", err)
+			return nil, fmt.Errorf("parse command arguments: %w", err)
 		}
 
-		if err := generate(buf, pls); err != nil {
+		filename := filepath.Clean(filepath.Join(filepath.Dir(pls.Filename), cfg.Filename()))
+
+		// Mocks generated outside of the interface's directory belong to another package.
+		local := filepath.Dir(filename) == filepath.Dir(pls.Filename)
+
+		buf.WriteString(pls.FormatDoNotEditHeader(name))
+
+		if local {
+			buf.WriteString(pls.FormatPkg())
+		} else {
+			buf.WriteString("package " + cfg.Pkg + "\n\n")
+		}
+
+		if err := generate(buf, pls, cfg, local); err != nil {
 			return nil, fmt.Errorf("generate: %w", err)
 		}
 
+		data, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("format source: %w", err)
+		}
+
 		files = append(files, gen.File{
-			Name: filepath.Clean(filepath.Join(filepath.Dir(pls.Filename), cfg.Filename())),
-			Data: bytes.Clone(buf.Bytes()),
+			Name: filename,
+			Data: data,
 		})
 
 		select {
@@ -50,79 +69,111 @@ func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]g
 	return files, nil
 }
 
-func generate(buf *bytes.Buffer, pls gen.Please) error {
-	usedImports := map[gen.PkgPath]gen.PkgName{}
+func generate(buf *bytes.Buffer, pls gen.Please, cfg config, local bool) error {
+	var pkg *types.Package
 
-	info, err := analyze(pls, usedImports)
+	if local {
+		pkg = pls.TS.Pkg.Types
+	}
+
+	imports := gen.NewImports(pkg, pls.Imports)
+	imports.Reserve(
+		"mock", "impl", "fn", "res", "ok", "returns", "call", "i",
+		"log", "c", "a", "b", "seq", "start", "end", "callInfo",
+	)
+
+	ifaces, err := analysis.InterfacesOf([]gen.Please{pls}, imports,
+		"mock", "fn", "seq", "start", "end", "callInfo", "res", "returns", "ok", "call",
+	)
 	if err != nil {
 		return fmt.Errorf("analyze AST: %w", err)
 	}
 
-	if len(usedImports) > 0 {
-		genImports(buf, usedImports)
+	if cfg.Sorted {
+		ifaces[0].SortMethods()
 	}
 
-	if err := genBody(buf, info); err != nil {
-		return fmt.Errorf("generate body: %w", err)
+	if cfg.History {
+		if err := ifaces[0].CheckNotMethods("CallLog"); err != nil {
+			return fmt.Errorf("history: %w", err)
+		}
 	}
 
-	return nil
-}
-
-func genImports(buf *bytes.Buffer, usedImports map[gen.PkgPath]gen.PkgName) {
-	pkgs := slices.Sorted(maps.Keys(usedImports))
-
-	buf.WriteString("import (\n")
+	for _, meth := range ifaces[0].Methods {
+		imports.Reserve(meth.IndexedResultNames()...)
+	}
 
-	for _, pkg := range pkgs {
-		buf.WriteByte('\t')
+	info, err := analyze(ifaces[0], imports)
+	if err != nil {
+		return fmt.Errorf("analyze: %w", err)
+	}
 
-		alias := usedImports[pkg]
-		if alias != "" {
-			buf.WriteString(string(alias))
-			buf.WriteByte(' ')
+	if cfg.Spy {
+		qualName, err := spyIfaceName(info, imports, local)
+		if err != nil {
+			return fmt.Errorf("spy: %w", err)
 		}
 
-		buf.WriteByte('"')
-		buf.WriteString(string(pkg))
-		buf.WriteString("\"\n")
+		info.qualName = qualName
 	}
 
-	buf.WriteString(")\n\n")
-}
+	body := bytes.NewBuffer(nil)
+
+	if err := genBody(body, imports, info, cfg); err != nil {
+		return fmt.Errorf("generate body: %w", err)
+	}
 
-func genBody(buf *bytes.Buffer, inf ifaceInfo) error {
-	var concrname string
+	imports.Write(buf)
+	buf.Write(body.Bytes())
 
-	const proxy = "Mock"
+	return nil
+}
 
-	startChar := string([]rune(inf.name)[0])
+// spyIfaceName returns the interface name qualified for the generated package.
+// The wrapped implementation can be called outside of its package only by exported methods.
+func spyIfaceName(info ifaceInfo, imports *gen.Imports, local bool) (string, error) {
+	if local {
+		return info.name, nil
+	}
 
-	if upper := strings.ToUpper(startChar); startChar != upper {
-		if len(inf.name) > 1 {
-			concrname = proxy + upper + inf.name[1:]
-		} else {
-			concrname = proxy + upper
+	for _, minf := range info.methInfos {
+		if !token.IsExported(minf.Name) {
+			return "", fmt.Errorf("%s: unexported method %s can't be delegated from package outside of %s",
+				info.pos, minf.Name, info.pkg.Path())
 		}
-	} else {
-		concrname = proxy + inf.name
 	}
 
+	return imports.Qualifier(info.pkg) + "." + info.name, nil
+}
+
+func genBody(buf *bytes.Buffer, imports *gen.Imports, inf ifaceInfo, cfg config) error {
+	concrname := analysis.ConcreteName("Mock", inf.name)
+
 	data := struct {
-		ConcrName      string
-		InterfaceName  string
-		TypeParamsDecl string
-		TypeParams     string
-		Methods        []methInfo
+		ConcrName         string
+		InterfaceName     string
+		QualInterfaceName string
+		TypeParamsDecl    string
+		TypeParams        string
+		Methods           []methInfo
+		Spy               bool
+		History           bool
+		Returns           bool
 	}{
-		ConcrName:      concrname,
-		InterfaceName:  inf.name,
-		TypeParamsDecl: inf.typeParamsDecl,
-		TypeParams:     inf.typeParams,
-		Methods:        inf.methInfos,
+		ConcrName:         concrname,
+		InterfaceName:     inf.name,
+		QualInterfaceName: inf.qualName,
+		TypeParamsDecl:    inf.typeParamsDecl,
+		TypeParams:        inf.typeParams,
+		Methods:           inf.methInfos,
+		Spy:               cfg.Spy,
+		History:           cfg.History,
+		Returns: slices.ContainsFunc(inf.methInfos, func(minf methInfo) bool {
+			return minf.Ret
+		}),
 	}
 
-	if err := tmpl.Execute(buf, data); err != nil {
+	if err := imports.Execute(buf, tmpl, data); err != nil {
 		return fmt.Errorf("execute template: %w", err)
 	}
 
@@ -0,0 +1,52 @@
+package mock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/internal/gentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const src = `package p
+
+type Store interface {
+	Get(id string) (string, error)
+	GetReturns(id string) bool
+}
+
+type Closer interface {
+	Close()
+	CloseReturns(err error)
+}
+
+type Logger interface {
+	CallLog(msg string)
+}
+`
+
+func TestGenerate_returnsClash(t *testing.T) {
+	t.Parallel()
+
+	_, err := Generate(context.Background(), "mock", []gen.Please{gentest.Please(t, src, "Store")})
+	assert.ErrorContains(t, err, "/p/p.go:3:6: generated GetReturns clashes with the method of the interface")
+
+	// The results of the methods without results are not queued.
+	files, err := Generate(context.Background(), "mock", []gen.Please{gentest.Please(t, src, "Closer")})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
+
+func TestGenerate_callLogClash(t *testing.T) {
+	t.Parallel()
+
+	_, err := Generate(context.Background(), "mock", []gen.Please{gentest.Please(t, src, "Logger", "-history")})
+	assert.ErrorContains(t, err, "generated CallLog clashes with the method of the interface")
+
+	// CallLog is generated by -history only.
+	files, err := Generate(context.Background(), "mock", []gen.Please{gentest.Please(t, src, "Logger")})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
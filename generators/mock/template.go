@@ -1,31 +1,182 @@
 package mock
 
-import "text/template"
+import (
+	"text/template"
+
+	"github.com/WinPooh32/genpls/gen"
+)
 
 //nolint:lll
 const tmplText = `// *{{.ConcrName}} implements {{.InterfaceName}}.
 type {{.ConcrName}}{{.TypeParamsDecl}} struct {
-{{range .Methods}}	{{.Name}}Func func {{.Sig}}
+{{range .Methods}}	{{.Name}}Func func{{.Sig}}
 {{end}}
 	Calls struct{
-{{range .Methods}}		{{.Name}} []struct{ {{.ArgsSig}} 
+{{range .Methods}}		{{.Name}} []struct{ {{.ArgsSig}}{{if or $.Spy $.History}}{{.ResultsSig}}{{end}}{{if $.History}}
+			Seq uint64
+			Start {{pkg "time"}}.Time
+			End {{pkg "time"}}.Time{{end}}
 		}
 {{end}}	}
+{{- if .Spy}}
+
+	impl {{.QualInterfaceName}}{{.TypeParams}}
+{{- end}}
+{{- if .History}}
+
+	seq uint64
+{{- end}}
+{{- if .Returns}}
+
+	returns struct{
+{{range .Methods}}{{if .Ret}}		{{.Name}} struct{
+			queue  []struct{ {{.ResultsSig}}
+			}
+			onCall map[int]struct{ {{.ResultsSig}}
+			}
+			next   int
+		}
+{{end}}{{end}}	}
+{{- end}}
+}
+{{if .Spy}}
+// New{{.ConcrName}} returns a new *{{.ConcrName}} which calls impl for the methods with nil funcs.
+func New{{.ConcrName}}{{.TypeParamsDecl}}(impl {{.QualInterfaceName}}{{.TypeParams}}) *{{.ConcrName}}{{.TypeParams}} {
+	return &{{.ConcrName}}{{.TypeParams}}{impl: impl}
 }
+{{end}}
+{{- if .History}}
+// {{.ConcrName}}Call is a record of the {{.ConcrName}}'s method call.
+type {{.ConcrName}}Call struct {
+	Method  string
+	Seq     uint64
+	Start   {{pkg "time"}}.Time
+	End     {{pkg "time"}}.Time
+	Args    []any
+	Results []any
+}
+
+// CallLog returns calls of all methods ordered by the sequence number.
+func (mock *{{.ConcrName}}{{.TypeParams}}) CallLog() []{{.ConcrName}}Call {
+	var log []{{.ConcrName}}Call
 {{range .Methods}}
-func (mock *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
-	if mock.{{.Name}}Func == nil {
+	for _, c := range mock.Calls.{{.Name}} {
+		log = append(log, {{$.ConcrName}}Call{
+			Method:  "{{.Name}}",
+			Seq:     c.Seq,
+			Start:   c.Start,
+			End:     c.End,
+			Args:    []any{ {{range $i, $n := .ArgNames}}{{if $i}}, {{end}}c.{{$n}}{{end}} },
+			Results: []any{ {{range $i, $n := .ResultNames}}{{if $i}}, {{end}}c.{{$n}}{{end}} },
+		})
+	}
+{{end}}
+	{{pkg "slices"}}.SortFunc(log, func(a, b {{.ConcrName}}Call) int {
+		return {{pkg "cmp"}}.Compare(a.Seq, b.Seq)
+	})
+
+	return log
+}
+{{end}}
+{{- range .Methods}}
+{{- if .Ret}}
+// {{.Name}}Returns queues results returned by the next calls of {{.Name}} while {{.Name}}Func is nil.
+func (mock *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}Returns({{.ResultsParams}}) {
+	mock.returns.{{.Name}}.queue = append(mock.returns.{{.Name}}.queue, struct{ {{.ResultsSig}}
+	} { {{.Results}} })
+}
+
+// {{.Name}}ReturnsOnCall sets results returned by the i-th (zero-based) call of {{.Name}} while {{.Name}}Func is nil.
+func (mock *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}ReturnsOnCall(i int, {{.ResultsParams}}) {
+	if mock.returns.{{.Name}}.onCall == nil {
+		mock.returns.{{.Name}}.onCall = map[int]struct{ {{.ResultsSig}}
+		}{}
+	}
+
+	mock.returns.{{.Name}}.onCall[i] = struct{ {{.ResultsSig}}
+	} { {{.Results}} }
+}
+
+// queued{{.Name}} returns func returning results queued for the call of {{.Name}}.
+// Returns nil if no results are set for the call and none are queued by {{.Name}}Returns.
+func (mock *{{$.ConcrName}}{{$.TypeParams}}) queued{{.Name}}(call int) func{{.Sig}} {
+	returns := &mock.returns.{{.Name}}
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method {{.Name}} are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func{{.Sig}} {
+		return {{range $i, $n := .ResultNames}}{{if $i}}, {{end}}res.{{$n}}{{end}}
+	}
+}
+{{end}}
+{{.Doc}}func (mock *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+	fn := mock.{{.Name}}Func
+{{- if .Ret}}
+	if fn == nil {
+		fn = mock.queued{{.Name}}(len(mock.Calls.{{.Name}}))
+	}
+{{- end}}
+{{- if $.Spy}}
+	if fn == nil {
+		if mock.impl == nil {
+			panic("nil method {{.Name}} is called!")
+		}
+
+		fn = mock.impl.{{.Name}}
+	}
+{{- else}}
+	if fn == nil {
 		panic("nil method {{.Name}} is called!")
 	}
+{{- end}}
+{{- if or $.Spy $.History}}
+{{- if $.History}}
+
+	mock.seq++
+	seq := mock.seq
+	start := {{pkg "time"}}.Now()
+{{- end}}
+
+	{{if .Ret}}{{.Results}} := {{end}}fn({{.CallArgs}})
+{{- if $.History}}
+
+	end := {{pkg "time"}}.Now()
+{{- end}}
+
+	callInfo := struct{ {{.ArgsSig}}{{.ResultsSig}}{{if $.History}}
+			Seq uint64
+			Start {{pkg "time"}}.Time
+			End {{pkg "time"}}.Time{{end}}
+	} { {{.Args}}{{if and .Args .Ret}}, {{end}}{{.Results}}{{if $.History}}{{if or .Args .Ret}}, {{end}}seq, start, end{{end}} }
+
+	mock.Calls.{{.Name}} = append(mock.Calls.{{.Name}}, callInfo)
+{{- if .Ret}}
+
+	return {{.Results}}
+{{- end}}
+{{- else}}
 
 	callInfo := struct{ {{.ArgsSig}}
 	} { {{.Args}} }
 
 	mock.Calls.{{.Name}} = append(mock.Calls.{{.Name}}, callInfo)
 
-	{{if .Ret}}return mock.{{.Name}}Func({{.Args}}){{else}}mock.{{.Name}}Func(){{end}}
+	{{if .Ret}}return {{end}}fn({{.CallArgs}})
+{{- end}}
 }
 {{end}}
 `
 
-var tmpl = template.Must(template.New("mock").Parse(tmplText))
+var tmpl = template.Must(template.New("mock").Funcs(gen.TemplateFuncs).Parse(tmplText))
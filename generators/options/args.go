@@ -0,0 +1,28 @@
+package options
+
+import (
+	"flag"
+	"fmt"
+)
+
+type config struct {
+	Option string
+	Prefix string
+}
+
+func parseArgs(arguments []string, defaultValue config) (config, error) {
+	var cfg config
+
+	flagset := flag.NewFlagSet("", flag.ContinueOnError)
+
+	flagset.StringVar(&cfg.Option, "option", defaultValue.Option,
+		"name of the func type configuring the struct")
+	flagset.StringVar(&cfg.Prefix, "prefix", defaultValue.Prefix,
+		"prefix of the names of the funcs setting the fields")
+
+	if err := flagset.Parse(arguments); err != nil {
+		return config{}, fmt.Errorf("flagset: Parse: %w", err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,387 @@
+package options
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/gen/analysis"
+)
+
+const (
+	// tagDefault is the struct tag key of the field's default value like `default:"10s"`.
+	tagDefault = "default"
+	// optSkip is the genpls tag option of the field which has no option.
+	optSkip = "-"
+)
+
+// fieldInfo is the field configured by the option.
+type fieldInfo struct {
+	gen.Field
+	// Func is the name of the option func.
+	Func string
+	// Default is the default value or nil if there is no default tag.
+	Default gen.Code
+}
+
+func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]gen.File, error) {
+	return gen.GenerateSources(ctx, name, gp, generate)
+}
+
+func generate(src *gen.Source, gp []gen.Please, out string) error {
+	src.Imports.Reserve("c", "v", "opts", "opt", "err")
+
+	// declared are the struct names by the names of the declared options types and funcs.
+	declared := map[string]string{}
+
+	for _, pls := range gp {
+		strct, err := analysis.StructOf(pls)
+		if err != nil {
+			return fmt.Errorf("analyze: %w", err)
+		}
+
+		exported := token.IsExported(strct.Name)
+
+		cfg, err := parseArgs(pls.Args, config{
+			Option: exportedName("Option", exported),
+			Prefix: exportedName("With", exported),
+		})
+		if err != nil {
+			return fmt.Errorf("parse command arguments: %w", err)
+		}
+
+		fields, err := analyze(strct, cfg, src.Imports)
+		if err != nil {
+			return err
+		}
+
+		names := []string{cfg.Option, constructorName(strct)}
+
+		for _, field := range fields {
+			names = append(names, field.Func)
+		}
+
+		for _, name := range names {
+			if other, ok := declared[name]; ok {
+				return fmt.Errorf("%s: %s is declared by the options of %s and %s", strct.Pos, name, other, strct.Name)
+			}
+
+			if obj := pls.TS.Pkg.Types.Scope().Lookup(name); obj != nil {
+				// The previously generated options are redeclared.
+				if pos := pls.TS.Pkg.Fset.Position(obj.Pos()); filepath.Clean(pos.Filename) != filepath.Clean(out) {
+					return fmt.Errorf("%s: %s of the options of %s is already declared at %s", strct.Pos, name, strct.Name, pos)
+				}
+			}
+
+			declared[name] = strct.Name
+		}
+
+		src.Decls = append(src.Decls, optionsDecls(strct, fields, cfg)...)
+	}
+
+	return nil
+}
+
+// analyze returns the fields configured by the options.
+func analyze(strct analysis.Struct, cfg config, imports *gen.Imports) ([]fieldInfo, error) {
+	fields := make([]fieldInfo, 0, len(strct.Fields))
+
+	for _, field := range strct.Fields {
+		if field.Name == "_" || field.HasOption(optSkip) {
+			continue
+		}
+
+		info := fieldInfo{
+			Field: field,
+			Func:  cfg.Prefix + analysis.UpperFirst(field.Name),
+		}
+
+		if tag, ok := field.Tag.Lookup(tagDefault); ok {
+			value, err := defaultValue(field.Type, tag, imports)
+			if err != nil {
+				return nil, fmt.Errorf("%s: default value of field %s: %w", strct.Pos, field.Name, err)
+			}
+
+			info.Default = value
+		}
+
+		fields = append(fields, info)
+	}
+
+	return fields, nil
+}
+
+// optionsDecls returns the declarations of the option type, the constructor and the options funcs.
+func optionsDecls(strct analysis.Struct, fields []fieldInfo, cfg config) []gen.Code {
+	typeParams := strct.TypeParamList()
+	typ := analysis.Instance(strct.Named, typeParams)
+	option := gen.Instance(cfg.Option, typeParams)
+	constructor := constructorName(strct)
+
+	optionSig := types.NewSignatureType(nil, nil, nil,
+		types.NewTuple(types.NewParam(token.NoPos, nil, "", types.NewPointer(typ))), nil, false)
+
+	decls := []gen.Code{
+		gen.TypeDecl{
+			Doc:        cfg.Option + " configures " + strct.Name + " created by " + constructor + ".",
+			Name:       cfg.Option,
+			TypeParams: typeParams,
+			Type:       gen.FuncType(optionSig),
+		},
+	}
+
+	var defaults []gen.Code
+
+	for _, field := range fields {
+		if field.Default != nil {
+			defaults = append(defaults, gen.KeyValue(gen.Ident(field.Name), field.Default))
+		}
+	}
+
+	doc := constructor + " returns a new *" + strct.Name + " configured by the options."
+	if len(defaults) > 0 {
+		doc += "\nThe options are applied over the default values."
+	}
+
+	body := []gen.Code{
+		gen.Define(gen.Ident("c"), gen.Addr(gen.Composite(gen.Type(typ), defaults...))),
+		gen.Line(),
+		gen.Range(nil, gen.Ident("opt"), gen.Ident("opts"),
+			gen.Line(gen.Call(gen.Ident("opt"), gen.Ident("c"))),
+		),
+		gen.Line(),
+	}
+
+	results := []gen.Param{{Type: gen.Ptr(gen.Type(typ))}}
+
+	// The constructor returns the validation error if the struct has the validation hook.
+	if hasValidate(typ) {
+		doc += "\nIt returns the error of the Validate method of the configured " + strct.Name + "."
+
+		body = append(body,
+			gen.If(
+				gen.Define(gen.Ident("err"), gen.Call(gen.Sel(gen.Ident("c"), "Validate"))),
+				gen.Op(gen.Ident("err"), "!=", gen.Ident("nil")),
+				gen.Return(gen.Ident("nil"), gen.Ident("err")),
+			),
+			gen.Line(),
+			gen.Return(gen.Ident("c"), gen.Ident("nil")),
+		)
+
+		results = append(results, gen.Param{Type: gen.Ident("error")})
+	} else {
+		body = append(body, gen.Return(gen.Ident("c")))
+	}
+
+	decls = append(decls, gen.FuncDecl{
+		Doc:        doc,
+		Name:       constructor,
+		TypeParams: typeParams,
+		Params:     []gen.Param{{Name: "opts", Type: option}},
+		Variadic:   true,
+		Results:    results,
+		Body:       body,
+	})
+
+	for _, field := range fields {
+		doc := field.Func + " sets " + field.Name + "."
+		if field.Doc != nil {
+			doc += "\n\n" + field.Doc.Text()
+		}
+
+		decls = append(decls, gen.FuncDecl{
+			Doc:        doc,
+			Name:       field.Func,
+			TypeParams: typeParams,
+			Params:     []gen.Param{{Name: "v", Type: gen.Type(field.Type)}},
+			Results:    []gen.Param{{Type: option}},
+			Body: []gen.Code{
+				gen.Return(gen.FuncLit{
+					Params: []gen.Param{{Name: "c", Type: gen.Ptr(gen.Type(typ))}},
+					Body: []gen.Code{
+						gen.Assign(gen.Sel(gen.Ident("c"), field.Name), gen.Ident("v")),
+					},
+				}),
+			},
+		})
+	}
+
+	return decls
+}
+
+// constructorName returns the name of the func creating the struct.
+func constructorName(strct analysis.Struct) string {
+	return exportedName("New"+analysis.UpperFirst(strct.Name), token.IsExported(strct.Name))
+}
+
+// defaultValue returns the literal of the default value parsed as the constant of the type.
+// Durations are parsed by [time.ParseDuration].
+func defaultValue(typ types.Type, tag string, imports *gen.Imports) (gen.Code, error) {
+	if isDuration(typ) {
+		d, err := time.ParseDuration(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		return gen.Duration(d), nil
+	}
+
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not supported", typ)
+	}
+
+	info := basic.Info()
+
+	switch {
+	case info&types.IsString != 0:
+		return gen.Lit(constant.MakeString(tag)), nil
+	case info&types.IsBoolean != 0:
+		b, err := strconv.ParseBool(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		return gen.Lit(constant.MakeBool(b)), nil
+	case info&types.IsNumeric != 0:
+		v, err := numericLiteral(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		if !representable(v, basic) {
+			return nil, fmt.Errorf("%s can't be represented by type %s", tag, typ)
+		}
+
+		// The literal is written as is to keep its precision and base.
+		return gen.Raw(strings.TrimSpace(tag)), nil
+	}
+
+	return nil, fmt.Errorf("type %s is not supported", typ)
+}
+
+// numericLiteral parses the numeric literal optionally signed.
+func numericLiteral(s string) (constant.Value, error) {
+	expr, err := parser.ParseExpr(s)
+	if err != nil {
+		return nil, fmt.Errorf("parse %q: %w", s, err)
+	}
+
+	op := token.ADD
+
+	if unary, ok := expr.(*ast.UnaryExpr); ok && (unary.Op == token.ADD || unary.Op == token.SUB) {
+		op = unary.Op
+		expr = unary.X
+	}
+
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind == token.STRING || lit.Kind == token.CHAR {
+		return nil, fmt.Errorf("%q is not a numeric literal", s)
+	}
+
+	v := constant.MakeFromLiteral(lit.Value, lit.Kind, 0)
+	if v.Kind() == constant.Unknown {
+		return nil, fmt.Errorf("%q is not a numeric literal", s)
+	}
+
+	return constant.UnaryOp(op, v, 0), nil
+}
+
+// representable reports whether the constant can be represented by the numeric type.
+func representable(v constant.Value, basic *types.Basic) bool {
+	info := basic.Info()
+
+	switch {
+	case info&types.IsInteger != 0:
+		v = constant.ToInt(v)
+		if v.Kind() != constant.Int {
+			return false
+		}
+
+		// The sizes of int, uint and uintptr depend on the target architecture.
+		bits := 64
+		if sizes := types.SizesFor("gc", build.Default.GOARCH); sizes != nil {
+			bits = int(sizes.Sizeof(basic)) * 8
+		}
+
+		if info&types.IsUnsigned != 0 {
+			limit := constant.Shift(constant.MakeUint64(1), token.SHL, uint(bits))
+
+			return constant.Sign(v) >= 0 && constant.Compare(v, token.LSS, limit)
+		}
+
+		limit := constant.Shift(constant.MakeInt64(1), token.SHL, uint(bits-1))
+
+		return constant.Compare(v, token.LSS, limit) &&
+			constant.Compare(v, token.GEQ, constant.UnaryOp(token.SUB, limit, 0))
+	case info&types.IsFloat != 0:
+		v = constant.ToFloat(v)
+
+		return v.Kind() == constant.Float && finite(v, basic.Kind() == types.Float32)
+	case info&types.IsComplex != 0:
+		v = constant.ToComplex(v)
+		single := basic.Kind() == types.Complex64
+
+		return v.Kind() == constant.Complex && finite(constant.Real(v), single) && finite(constant.Imag(v), single)
+	}
+
+	return false
+}
+
+// finite reports whether the float constant doesn't overflow float64 or float32 if single is true.
+func finite(v constant.Value, single bool) bool {
+	if single {
+		f, _ := constant.Float32Val(v)
+
+		return !math.IsInf(float64(f), 0)
+	}
+
+	f, _ := constant.Float64Val(v)
+
+	return !math.IsInf(f, 0)
+}
+
+// hasValidate reports whether the pointer to the struct has the validation hook Validate() error.
+func hasValidate(typ types.Type) bool {
+	sel := types.NewMethodSet(types.NewPointer(typ)).Lookup(nil, "Validate")
+	if sel == nil {
+		return false
+	}
+
+	sig, ok := sel.Type().(*types.Signature)
+
+	return ok && sig.Params().Len() == 0 && sig.Results().Len() == 1 && analysis.IsError(sig.Results().At(0).Type())
+}
+
+func isDuration(typ types.Type) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Duration"
+}
+
+// exportedName returns the name with the first letter upper cased if it's exported or lower cased otherwise.
+func exportedName(name string, exported bool) string {
+	if exported {
+		return analysis.UpperFirst(name)
+	}
+
+	r := []rune(name)
+
+	return strings.ToLower(string(r[0])) + string(r[1:])
+}
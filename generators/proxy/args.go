@@ -0,0 +1,71 @@
+package proxy
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+// Loggers used by the generated proxies.
+const (
+	loggerLog  = "log"
+	loggerSlog = "slog"
+)
+
+type config struct {
+	Logger      string
+	Interceptor bool
+	Redact      gen.ArgSet
+	Timing      bool
+	Threshold   time.Duration
+	Sorted      bool
+}
+
+func parseArgs(arguments []string, defaultValue config) (config, error) {
+	var cfg config
+
+	flagset := flag.NewFlagSet("", flag.ContinueOnError)
+
+	flagset.StringVar(&cfg.Logger, "logger", defaultValue.Logger, "logger used by the proxy: log or slog")
+	flagset.BoolVar(&cfg.Interceptor, "interceptor", defaultValue.Interceptor, "intercept calls instead of logging")
+	flagset.Var(&cfg.Redact, "redact", "comma separated list of parameters names which values are not logged")
+	flagset.BoolVar(&cfg.Timing, "timing", defaultValue.Timing, "log duration of calls")
+	flagset.DurationVar(&cfg.Threshold, "threshold", defaultValue.Threshold,
+		"log only calls lasting at least the threshold duration, enables timing")
+	flagset.BoolVar(&cfg.Sorted, "sorted", defaultValue.Sorted, gen.SortedUsage)
+
+	if err := flagset.Parse(arguments); err != nil {
+		return config{}, fmt.Errorf("flagset: Parse: %w", err)
+	}
+
+	switch cfg.Logger {
+	case loggerLog, loggerSlog:
+	default:
+		return config{}, fmt.Errorf("unknown logger %q", cfg.Logger)
+	}
+
+	if cfg.Interceptor && cfg.Logger != defaultValue.Logger {
+		return config{}, errors.New("interceptor proxy doesn't use logger")
+	}
+
+	if cfg.Threshold < 0 {
+		return config{}, fmt.Errorf("negative threshold %s", cfg.Threshold)
+	}
+
+	if cfg.Threshold > 0 {
+		cfg.Timing = true
+	}
+
+	if cfg.Interceptor && cfg.Timing {
+		return config{}, errors.New("interceptor proxy doesn't support timing")
+	}
+
+	if cfg.Interceptor && len(cfg.Redact) > 0 {
+		return config{}, errors.New("interceptor proxy doesn't log arguments to redact")
+	}
+
+	return cfg, nil
+}
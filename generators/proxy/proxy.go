@@ -6,299 +6,396 @@ import (
 	"fmt"
 	"go/ast"
 	"go/types"
-	"maps"
 	"slices"
 	"strconv"
 	"strings"
+	"text/template"
+	"time"
+	"unicode"
 
 	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/gen/analysis"
 )
 
 func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]gen.File, error) {
-	var files []gen.File
-
-	buf := bytes.NewBuffer(nil)
-
-	for filename, gp := range gen.IterateFiles(gp) {
-		buf.Reset()
-		buf.WriteString(gp[0].FormatDoNotEditHeader(name))
-		buf.WriteString(gp[0].FormatPkg())
-
-		if err := generate(buf, gp); err != nil {
-			return nil, fmt.Errorf("generate: %w", err)
-		}
-
-		files = append(files, gen.File{
-			Name: gp[0].FormatGeneratorFileName(name, strings.HasSuffix(filename, "_test.go")),
-			Data: bytes.Clone(buf.Bytes()),
-		})
-
-		select {
-		case <-ctx.Done():
-			return nil, fmt.Errorf("context is closed: %w", ctx.Err())
-		default:
-		}
-	}
-
-	return files, nil
+	return gen.GenerateFiles(ctx, name, gp, generate)
 }
 
 type methInfo struct {
-	Name    string
+	Name string
+	// Doc is the method doc as the comment lines.
+	Doc     string
 	Sig     string
 	Args    string
 	Results string
 	Ret     bool
+
+	// Ctx is the name of the leading context.Context parameter.
+	Ctx string
+	// ArgAttrs is the list of slog attributes of parameters.
+	ArgAttrs string
+	// SlogResults is the list of results names where the trailing error is named err.
+	SlogResults string
+	// ResultAttrs is the list of slog attributes of results.
+	ResultAttrs string
+	// Err reports whether the trailing result is error.
+	Err bool
+	// CtxlessArgs is the list of parameters names except the leading context.
+	CtxlessArgs string
+	// ResultTypes is the list of results types.
+	ResultTypes []string
+	// ResultNames are the results names matching the types.
+	ResultNames []string
+	// ResultNilable reports whether the results matching the types are interfaces which may be nil.
+	ResultNilable []bool
+	// LogArgs is the list of logged parameters where redacted ones are replaced by the placeholder.
+	LogArgs string
+	// LogResults is the list of logged results where redacted ones are replaced by the placeholder.
+	LogResults string
+	// CallAttrs is the list of slog attributes of parameters and results.
+	CallAttrs string
 }
 
 type ifaceInfo struct {
 	name           string
-	object         types.Object
 	methInfos      []methInfo
 	typeParamsDecl string
 	typeParams     string
+	logger         string
+	interceptor    bool
+	timing         bool
+	threshold      time.Duration
 }
 
 func generate(buf *bytes.Buffer, gp []gen.Please) error {
-	usedImports := map[gen.PkgPath]gen.PkgName{
-		gen.PkgPath("errors"): gen.PkgName(""),
+	imports := gen.NewImports(gp[0].TS.Pkg.Types, gp[0].Imports)
+	imports.Reserve("p", "v", "logger", "interceptor", "res", "ok", "start", "elapsed", "level", "err")
+
+	ifaces, err := analysis.InterfacesOf(gp, imports,
+		"p", "res", "ok", "start", "elapsed", "level", "err", "logger", "interceptor",
+	)
+	if err != nil {
+		return fmt.Errorf("analyze: %w", err)
+	}
+
+	for _, iface := range ifaces {
+		for _, meth := range iface.Methods {
+			imports.Reserve(meth.IndexedResultNames()...)
+		}
 	}
+
 	infos := make([]ifaceInfo, 0, len(gp))
 
-	for _, pls := range gp {
-		info, err := analyze(pls, usedImports)
+	for i, pls := range gp {
+		cfg, err := parseArgs(pls.Args, config{
+			Logger: loggerLog,
+		})
+		if err != nil {
+			return fmt.Errorf("parse command arguments: %w", err)
+		}
+
+		if cfg.Sorted {
+			ifaces[i].SortMethods()
+		}
+
+		info, err := analyze(ifaces[i], imports, cfg)
 		if err != nil {
 			return fmt.Errorf("analyze: %w", err)
 		}
 
+		info.logger = cfg.Logger
+		info.interceptor = cfg.Interceptor
+		info.timing = cfg.Timing
+		info.threshold = cfg.Threshold
+
 		infos = append(infos, info)
 	}
 
-	if len(usedImports) > 0 {
-		genImports(buf, usedImports)
-	}
+	body := bytes.NewBuffer(nil)
 
 	for _, inf := range infos {
-		if err := genLoggerProxy(buf, inf); err != nil {
+		if err := genLoggerProxy(body, imports, inf); err != nil {
 			return err
 		}
 	}
 
+	imports.Write(buf)
+	buf.Write(body.Bytes())
+
 	return nil
 }
 
-func analyze(pls gen.Please, usedImports map[gen.PkgPath]gen.PkgName) (ifaceInfo, error) {
-	origIfacename := pls.TS.Spec.Name.Name
-	position := pls.TS.Pkg.Fset.Position(pls.TS.Spec.Pos())
+func analyze(iface analysis.Interface, imports *gen.Imports, cfg config) (ifaceInfo, error) {
+	qf := imports.Qualifier
 
-	var spec *ast.TypeSpec
-
-	// Handle aliased interface
-	if ident, ok := pls.TS.Spec.Type.(*ast.Ident); ok {
-		typeSpec, okTypeSpec := ident.Obj.Decl.(*ast.TypeSpec)
-		if !okTypeSpec {
-			return ifaceInfo{}, fmt.Errorf("%s: type %q expected to be an interface alias", position, origIfacename)
-		}
-
-		spec = typeSpec
-	} else {
-		spec = pls.TS.Spec
+	// The attributes are rendered by the slog logger only.
+	var slog gen.PkgName
+	if cfg.Logger == loggerSlog {
+		slog = imports.Add("log/slog")
 	}
 
-	_, ok := spec.Type.(*ast.InterfaceType)
-	if !ok {
-		return ifaceInfo{}, fmt.Errorf("%s: type %q must be an interface", position, origIfacename)
+	if err := checkRedact(iface, cfg.Redact); err != nil {
+		return ifaceInfo{}, err
 	}
 
-	ifacename := spec.Name.Name
+	methInfos := make([]methInfo, 0, len(iface.Methods))
 
-	object := pls.TS.Pkg.Types.Scope().Lookup(ifacename)
-	if object == nil {
-		return ifaceInfo{}, fmt.Errorf("%s: object %s not found", position, ifacename)
-	}
+	for _, meth := range iface.Methods {
+		ret := len(meth.Results) > 0
 
-	if _, ok := object.(*types.TypeName); !ok {
-		return ifaceInfo{}, fmt.Errorf("%v is not a named type", object)
-	}
-
-	objtyp := object.Type()
-
-	typ, ok := objtyp.(*types.Named)
-	if !ok {
-		return ifaceInfo{}, fmt.Errorf("unexpected type %T", objtyp)
-	}
+		ctx := meth.Ctx()
 
-	pkgAliasFn := alias(pls.TS.Pkg.Types, pls.Imports, usedImports)
+		// The context is not logged, it is passed to the logger.
+		ctxlessArgs := meth.ArgNames()
+		argKeys := meth.DeclaredArgNames()
 
-	typeParamsDecl, typeParams := typeParams(typ, pkgAliasFn)
-
-	mset := types.NewMethodSet(objtyp)
+		if ctx != "" {
+			ctxlessArgs = ctxlessArgs[1:]
+			argKeys = argKeys[1:]
+		}
 
-	methInfos := make([]methInfo, 0, mset.Len())
+		results := meth.IndexedResultNames()
+		slogResults := extractSlogResults(meth)
+		resultKeys := extractResultKeys(meth)
 
-	for i := range mset.Len() {
-		meth := mset.At(i).Obj()
-		sig := types.TypeString(meth.Type(), pkgAliasFn)
+		markers := redactMarkers(meth.Doc)
 
-		sigtyp, ok := meth.Type().(*types.Signature)
-		if !ok {
-			return ifaceInfo{}, fmt.Errorf("unexpected type %T", meth.Type())
+		for _, name := range markers {
+			if !slices.Contains(meth.DeclaredArgNames(), name) {
+				return ifaceInfo{}, fmt.Errorf("%s: method %s: redacted %q is not a parameter", iface.Pos, meth.Name, name)
+			}
 		}
 
-		ret := sigtyp.Results().Len() > 0
+		redacted := redactedNames(meth, slices.Concat(cfg.Redact, markers))
 
 		methInfos = append(methInfos, methInfo{
-			Name:    meth.Name(),
-			Sig:     strings.TrimPrefix(sig, "func"),
-			Args:    extractArgs(sigtyp),
-			Results: extractResults(sigtyp),
-			Ret:     ret,
+			Name:        meth.Name,
+			Doc:         meth.DocComment(),
+			Sig:         meth.Sig(qf),
+			Args:        meth.Args(),
+			Results:     strings.Join(results, ", "),
+			Ret:         ret,
+			Ctx:         ctx,
+			ArgAttrs:    extractAttrs(slog, argKeys, ctxlessArgs, redacted),
+			SlogResults: strings.Join(slogResults, ", "),
+			ResultAttrs: extractAttrs(slog, resultKeys, slogResults, redacted),
+			LogArgs:     redact(meth.ArgNames(), redacted),
+			LogResults:  redact(results, redacted),
+			CallAttrs: strings.Join(slices.DeleteFunc([]string{
+				extractAttrs(slog, argKeys, ctxlessArgs, redacted),
+				extractAttrs(slog, resultKeys, slogResults, redacted),
+			}, func(attrs string) bool { return attrs == "" }), ", "),
+			Err:           meth.Err(),
+			CtxlessArgs:   strings.Join(ctxlessArgs, ", "),
+			ResultTypes:   meth.ResultTypes(qf),
+			ResultNames:   results,
+			ResultNilable: nilable(meth.Results),
 		})
 	}
 
 	return ifaceInfo{
-		name:           origIfacename,
-		object:         object,
+		name:           iface.Name,
 		methInfos:      methInfos,
-		typeParamsDecl: typeParamsDecl,
-		typeParams:     typeParams,
+		typeParamsDecl: analysis.TypeParamsDecl(iface.TypeParams, qf),
+		typeParams:     analysis.TypeArgs(iface.TypeParams),
 	}, nil
 }
 
-// extractArgs returns list of parameters names.
-func extractArgs(sig *types.Signature) string {
-	var args []string
+// checkRedact returns an error if any of the redacted names is not a parameter of the interface's methods,
+// so a misspelled name doesn't leave the value logged.
+func checkRedact(iface analysis.Interface, names []string) error {
+	for _, name := range names {
+		if !slices.ContainsFunc(iface.Methods, func(meth analysis.Method) bool {
+			return slices.Contains(meth.DeclaredArgNames(), name)
+		}) {
+			return fmt.Errorf("%s: redacted %q is not a parameter of any method", iface.Pos, name)
+		}
+	}
+
+	return nil
+}
+
+// nilable reports whether the results are interfaces which may be nil,
+// type parameters are instantiated by interfaces too.
+func nilable(results []analysis.Var) []bool {
+	nilable := make([]bool, len(results))
 
-	params := sig.Params()
+	for i, res := range results {
+		nilable[i] = types.IsInterface(res.Type)
+	}
+
+	return nilable
+}
 
-	for i := range params.Len() {
-		param := params.At(i)
-		args = append(args, param.Name())
+// extractSlogResults returns results names where the trailing error is named err.
+func extractSlogResults(meth analysis.Method) []string {
+	names := meth.IndexedResultNames()
+
+	if meth.Err() {
+		names[len(names)-1] = "err"
 	}
 
-	return strings.Join(args, ", ")
+	return names
 }
 
-// extractResults returns list of results names.
-func extractResults(sig *types.Signature) string {
-	var args []string
+// extractResultKeys returns the slog attributes keys of the results r0, r1, ... where the trailing error is keyed err.
+// The keys don't depend on the results names, which are renamed to avoid clashes.
+func extractResultKeys(meth analysis.Method) []string {
+	keys := make([]string, len(meth.Results))
 
-	results := sig.Results()
+	for i := range keys {
+		keys[i] = "r" + strconv.Itoa(i)
+	}
 
-	for i := range results.Len() {
-		args = append(args, "r"+strconv.Itoa(i))
+	if meth.Err() {
+		keys[len(keys)-1] = "err"
 	}
 
-	return strings.Join(args, ", ")
+	return keys
 }
 
-func alias(
-	pkg *types.Package,
-	imports map[gen.PkgPath]gen.PkgName,
-	usedImports map[gen.PkgPath]gen.PkgName,
-) types.Qualifier {
-	return func(p *types.Package) string {
-		if pkg == p {
-			// local imports are unqualified.
-			return ""
-		}
+// extractAttrs returns list of slog attributes of the named values keyed by the matching keys,
+// slog is the name of the imported log/slog package.
+func extractAttrs(slog gen.PkgName, keys, names []string, redacted map[string]bool) string {
+	var attrs []string
 
-		path := gen.PkgPath(p.Path())
-		alias := imports[path]
+	for i, name := range names {
+		key := strconv.Quote(keys[i])
 
-		// Populate imports used by generated types.
-		// Include empty alias too.
-		if usedImports != nil {
-			usedImports[path] = alias
+		switch {
+		case name == "":
+			continue
+		case redacted[name]:
+			attrs = append(attrs, string(slog)+".String("+key+", "+redactedPlaceholder+")")
+		default:
+			attrs = append(attrs, string(slog)+".Any("+key+", "+name+")")
 		}
+	}
 
-		if alias == "" {
-			return p.Name()
-		}
+	return strings.Join(attrs, ", ")
+}
 
-		return string(alias)
+// redactedPlaceholder is logged instead of the redacted value.
+const redactedPlaceholder = `"[REDACTED]"`
+
+// redactMarker marks redacted parameters at the method doc.
+const redactMarker = "//" + gen.CmdPrefix + "redact"
+
+// redactMarkers returns names of redacted parameters marked at the method doc.
+func redactMarkers(doc *ast.CommentGroup) []string {
+	if doc == nil {
+		return nil
 	}
-}
 
-func typeParams(typ *types.Named, pkgAliasFn types.Qualifier) (typeParamsDecl string, typeParams string) {
-	if typ.TypeParams().Len() > 0 {
-		typeParamsDecl = "["
-		typeParams = "["
-
-		for i := range typ.TypeParams().Len() {
-			param := typ.TypeParams().At(i)
-			constraintName := types.TypeString(param.Constraint(), pkgAliasFn)
-
-			if i == 0 {
-				typeParamsDecl += param.String() + " " + constraintName
-				typeParams += param.String()
-			} else {
-				typeParamsDecl += ", " + param.String() + " " + constraintName
-				typeParams += ", " + param.String()
-			}
+	var names []string
+
+	for _, line := range doc.List {
+		marked, ok := strings.CutPrefix(line.Text, redactMarker)
+		// The marker is a whole word, other directives like //genpls:redacted are not markers.
+		if !ok || marked != "" && strings.TrimLeftFunc(marked, unicode.IsSpace) == marked {
+			continue
 		}
 
-		typeParamsDecl += "]"
-		typeParams += "]"
+		for _, name := range strings.Split(marked, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
 	}
 
-	return typeParamsDecl, typeParams
+	return names
 }
 
-func genImports(buf *bytes.Buffer, usedImports map[gen.PkgPath]gen.PkgName) {
-	pkgs := slices.Sorted(maps.Keys(usedImports))
-
-	buf.WriteString("import (\n")
+// redactedNames returns names of parameters and results which are not logged.
+// Parameters are redacted by the given declared names,
+// parameters and results are redacted by types with Redacted method.
+func redactedNames(meth analysis.Method, names []string) map[string]bool {
+	redacted := map[string]bool{}
 
-	for _, pkg := range pkgs {
-		buf.WriteByte('\t')
+	declared := meth.DeclaredArgNames()
 
-		alias := usedImports[pkg]
-		if alias != "" {
-			buf.WriteString(string(alias))
-			buf.WriteByte(' ')
+	for i, param := range meth.Params {
+		if slices.Contains(names, declared[i]) || isRedactedType(param.Type) {
+			redacted[param.Name] = true
 		}
+	}
+
+	results := meth.IndexedResultNames()
+	slogResults := extractSlogResults(meth)
 
-		buf.WriteByte('"')
-		buf.WriteString(string(pkg))
-		buf.WriteString("\"\n")
+	for i, res := range meth.Results {
+		if isRedactedType(res.Type) {
+			redacted[results[i]] = true
+			redacted[slogResults[i]] = true
+		}
 	}
 
-	buf.WriteString(")\n\n")
+	return redacted
 }
 
-func genLoggerProxy(buf *bytes.Buffer, inf ifaceInfo) error {
-	var concrname string
+// isRedactedType reports whether the type has Redacted method.
+func isRedactedType(typ types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(typ, true, nil, "Redacted")
 
-	const proxy = "Proxy"
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
 
-	startChar := string([]rune(inf.name)[0])
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Params().Len() == 0
+}
 
-	if upper := strings.ToUpper(startChar); startChar != upper {
-		if len(inf.name) > 1 {
-			concrname = proxy + upper + inf.name[1:]
-		} else {
-			concrname = proxy + upper
+// redact replaces redacted names by the placeholder.
+func redact(names []string, redacted map[string]bool) string {
+	logged := slices.Clone(names)
+
+	for i, name := range logged {
+		if redacted[name] {
+			logged[i] = redactedPlaceholder
 		}
-	} else {
-		concrname = proxy + inf.name
 	}
 
+	return strings.Join(logged, ", ")
+}
+
+func genLoggerProxy(buf *bytes.Buffer, imports *gen.Imports, inf ifaceInfo) error {
+	concrname := analysis.ConcreteName("Proxy", inf.name)
+
 	data := struct {
 		ConcrName      string
 		InterfaceName  string
 		TypeParamsDecl string
 		TypeParams     string
 		Methods        []methInfo
+		Timing         bool
+		Threshold      string
 	}{
 		ConcrName:      concrname,
 		InterfaceName:  inf.name,
 		TypeParamsDecl: inf.typeParamsDecl,
 		TypeParams:     inf.typeParams,
 		Methods:        inf.methInfos,
+		Timing:         inf.timing,
+	}
+
+	if inf.threshold > 0 {
+		data.Threshold = imports.Render(gen.Duration(inf.threshold))
+	}
+
+	var t *template.Template
+
+	switch {
+	case inf.interceptor:
+		t = tmplInterceptor
+	case inf.logger == loggerSlog:
+		t = tmplSlog
+	default:
+		t = tmpl
 	}
 
-	if err := tmpl.Execute(buf, data); err != nil {
+	if err := imports.Execute(buf, t, data); err != nil {
 		return fmt.Errorf("execute template: %w", err)
 	}
 
@@ -0,0 +1,93 @@
+package proxy
+
+import (
+	"context"
+	"go/ast"
+	"testing"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/internal/gentest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const src = `package p
+
+import "context"
+
+type Auth interface {
+	Login(ctx context.Context, user, password string) error
+	// Refresh exchanges the token.
+	//
+	//genpls:redact tokn
+	Refresh(ctx context.Context, token string) error
+}
+
+type Logged interface {
+	Log(logger string) error
+}
+
+type Renamed interface {
+	Do(p string, _ int) (r0 int, err error)
+}
+`
+
+func TestRedactMarkers(t *testing.T) {
+	t.Parallel()
+
+	doc := &ast.CommentGroup{List: []*ast.Comment{
+		{Text: "// Login signs the user in."},
+		{Text: "//genpls:redact password, token"},
+		{Text: "//genpls:redact"},
+		{Text: "//genpls:redacted,user"},
+		{Text: "//genpls:redactor user"},
+	}}
+
+	assert.Equal(t, []string{"password", "token"}, redactMarkers(doc))
+}
+
+func TestParseArgs_interceptorRedact(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseArgs([]string{"-interceptor", "-redact=password"}, config{Logger: loggerLog})
+	assert.EqualError(t, err, "interceptor proxy doesn't log arguments to redact")
+}
+
+func TestGenerate_unknownRedact(t *testing.T) {
+	t.Parallel()
+
+	_, err := Generate(context.Background(), "proxy", []gen.Please{gentest.Please(t, src, "Auth", "-redact=pasword")})
+	assert.ErrorContains(t, err, `redacted "pasword" is not a parameter of any method`)
+}
+
+func TestGenerate_unknownRedactMarker(t *testing.T) {
+	t.Parallel()
+
+	_, err := Generate(context.Background(), "proxy", []gen.Please{gentest.Please(t, src, "Auth", "-redact=password")})
+	assert.ErrorContains(t, err, `method Refresh: redacted "tokn" is not a parameter`)
+}
+
+func TestGenerate_redactRenamed(t *testing.T) {
+	t.Parallel()
+
+	// The parameter logger is renamed by the proxy, it is redacted by the declared name.
+	files, err := Generate(context.Background(), "proxy", []gen.Please{gentest.Please(t, src, "Logged", "-redact=logger")})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	assert.Contains(t, string(files[0].Data), `p.logger.Log("Calling Log", "arguments", "[REDACTED]")`)
+}
+
+func TestGenerate_slogKeys(t *testing.T) {
+	t.Parallel()
+
+	// The attributes are keyed by the declared parameters names and the results indexes, not by the renamed locals.
+	files, err := Generate(context.Background(), "proxy", []gen.Please{gentest.Please(t, src, "Renamed", "-logger=slog")})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data := string(files[0].Data)
+
+	assert.Contains(t, data, `"Calling Do", slog.Any("p", p_1), slog.Any("a1", a1))`)
+	assert.Contains(t, data, `"Called Do", slog.Any("r0", r0_1), slog.Any("err", err))`)
+}
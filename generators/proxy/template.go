@@ -1,6 +1,10 @@
 package proxy
 
-import "text/template"
+import (
+	"text/template"
+
+	"github.com/WinPooh32/genpls/gen"
+)
 
 //nolint:lll
 const tmplText = `// *{{.ConcrName}} implements {{.InterfaceName}}.
@@ -11,10 +15,10 @@ type {{.ConcrName}}{{.TypeParamsDecl}} struct {
 
 func New{{.ConcrName}}{{.TypeParamsDecl}}(v {{.InterfaceName}}{{.TypeParams}}, logger interface{Log(string, ...any)}) (*{{.ConcrName}}{{.TypeParams}}, error) {
 	if v == nil {
-		return nil, errors.New("v is nil")
+		return nil, {{pkg "errors"}}.New("v is nil")
 	}
 	if logger == nil {
-		return nil, errors.New("logger is nil")
+		return nil, {{pkg "errors"}}.New("logger is nil")
 	}
 	return &{{.ConcrName}}{{.TypeParams}}{
 		v:      v,
@@ -22,14 +26,145 @@ func New{{.ConcrName}}{{.TypeParamsDecl}}(v {{.InterfaceName}}{{.TypeParams}}, l
 	}, nil
 }
 {{range .Methods}}
-func (p *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
-	p.logger.Log("Calling {{.Name}}", "arguments", {{.Args}})
+{{.Doc}}func (p *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+{{- if $.Timing}}
+{{- if not $.Threshold}}
+	p.logger.Log("Calling {{.Name}}", "arguments", {{.LogArgs}})
+{{- end}}
+	start := {{pkg "time"}}.Now()
+	{{if .Ret}}{{.Results}} := {{end}}p.v.{{.Name}}({{.Args}})
+	elapsed := {{pkg "time"}}.Since(start)
+{{- if $.Threshold}}
+	if elapsed >= {{$.Threshold}} {
+		p.logger.Log("Calling {{.Name}}", "arguments"{{if .LogArgs}}, {{.LogArgs}}{{end}}, "results"{{if .LogResults}}, {{.LogResults}}{{end}}, "elapsed", elapsed)
+	}
+{{- else}}
+	p.logger.Log("Calling {{.Name}}", "results"{{if .LogResults}}, {{.LogResults}}{{end}}, "elapsed", elapsed)
+{{- end}}
+{{- if .Ret}}
+	return {{.Results}}
+{{- end}}
+{{- else}}
+	p.logger.Log("Calling {{.Name}}", "arguments", {{.LogArgs}})
 	{{if .Ret}}{{.Results}} := p.v.{{.Name}}({{.Args}})
-	p.logger.Log("Calling {{.Name}}", "results", {{.Results}})
+	p.logger.Log("Calling {{.Name}}", "results", {{.LogResults}})
 	return {{.Results}}{{else}}p.v.{{.Name}}({{.Args}})
 	p.logger.Log("Calling {{.Name}}", "results"){{end}}
+{{- end}}
+}
+{{end}}
+`
+
+//nolint:lll
+const tmplSlogText = `// *{{.ConcrName}} implements {{.InterfaceName}}.
+type {{.ConcrName}}{{.TypeParamsDecl}} struct {
+	v      {{.InterfaceName}}{{.TypeParams}}
+	logger *{{pkg "log/slog"}}.Logger
+}
+
+func New{{.ConcrName}}{{.TypeParamsDecl}}(v {{.InterfaceName}}{{.TypeParams}}, logger *{{pkg "log/slog"}}.Logger) (*{{.ConcrName}}{{.TypeParams}}, error) {
+	if v == nil {
+		return nil, {{pkg "errors"}}.New("v is nil")
+	}
+	if logger == nil {
+		return nil, {{pkg "errors"}}.New("logger is nil")
+	}
+	return &{{.ConcrName}}{{.TypeParams}}{
+		v:      v,
+		logger: logger,
+	}, nil
+}
+{{range .Methods}}
+{{.Doc}}func (p *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+{{- $ctx := .Ctx}}{{if not $ctx}}{{$ctx = printf "%s.Background()" (pkg "context")}}{{end}}
+{{- if not $.Threshold}}
+	p.logger.LogAttrs({{$ctx}}, {{pkg "log/slog"}}.LevelInfo, "Calling {{.Name}}"{{if .ArgAttrs}}, {{.ArgAttrs}}{{end}})
+{{- end}}
+{{- if $.Timing}}
+	start := {{pkg "time"}}.Now()
+{{- end}}
+	{{if .Ret}}{{.SlogResults}} := {{end}}p.v.{{.Name}}({{.Args}})
+{{- if $.Timing}}
+	elapsed := {{pkg "time"}}.Since(start)
+{{- end}}
+{{- $attrs := .ResultAttrs}}{{if $.Threshold}}{{$attrs = .CallAttrs}}{{end}}
+{{- if $.Timing}}{{$elapsed := printf "%s.Duration(\"elapsed\", elapsed)" (pkg "log/slog")}}{{if $attrs}}{{$attrs = printf "%s, %s" $attrs $elapsed}}{{else}}{{$attrs = $elapsed}}{{end}}{{end}}
+{{- $level := printf "%s.LevelInfo" (pkg "log/slog")}}
+{{- if .Err}}{{$level = "level"}}
+	level := {{pkg "log/slog"}}.LevelInfo
+	if err != nil {
+		level = {{pkg "log/slog"}}.LevelError
+	}
+{{- end}}
+{{- if $.Threshold}}
+	if elapsed >= {{$.Threshold}} {
+		p.logger.LogAttrs({{$ctx}}, {{$level}}, "Called {{.Name}}"{{if $attrs}}, {{$attrs}}{{end}})
+	}
+{{- else}}
+	p.logger.LogAttrs({{$ctx}}, {{$level}}, "Called {{.Name}}"{{if $attrs}}, {{$attrs}}{{end}})
+{{- end}}
+{{- if .Ret}}
+	return {{.SlogResults}}
+{{- end}}
+}
+{{end}}
+`
+
+//nolint:lll
+const tmplInterceptorText = `// {{.ConcrName}}Interceptor intercepts calls of the *{{.ConcrName}} methods.
+// The method's arguments are passed by args except the leading context.
+// The next func calls the wrapped method and returns its results.
+// The interceptor must return as many results of the same types as next does, otherwise the method panics.
+// Nil is accepted for the results of interface types.
+type {{.ConcrName}}Interceptor func(ctx {{pkg "context"}}.Context, method string, args []any, next func() []any) []any
+
+// *{{.ConcrName}} implements {{.InterfaceName}}.
+type {{.ConcrName}}{{.TypeParamsDecl}} struct {
+	v           {{.InterfaceName}}{{.TypeParams}}
+	interceptor {{.ConcrName}}Interceptor
+}
+
+func New{{.ConcrName}}{{.TypeParamsDecl}}(v {{.InterfaceName}}{{.TypeParams}}, interceptor {{.ConcrName}}Interceptor) (*{{.ConcrName}}{{.TypeParams}}, error) {
+	if v == nil {
+		return nil, {{pkg "errors"}}.New("v is nil")
+	}
+	if interceptor == nil {
+		return nil, {{pkg "errors"}}.New("interceptor is nil")
+	}
+	return &{{.ConcrName}}{{.TypeParams}}{
+		v:           v,
+		interceptor: interceptor,
+	}, nil
+}
+{{range .Methods}}
+{{.Doc}}func (p *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+{{- $ctx := .Ctx}}{{if not $ctx}}{{$ctx = printf "%s.Background()" (pkg "context")}}{{end}}
+	{{if .Ret}}res := {{end}}p.interceptor({{$ctx}}, "{{.Name}}", []any{ {{.CtxlessArgs}} }, func() []any {
+		{{if .Ret}}{{.Results}} := p.v.{{.Name}}({{.Args}})
+		return []any{ {{.Results}} }{{else}}p.v.{{.Name}}({{.Args}})
+		return nil{{end}}
+	})
+{{- if .Ret}}
+
+	if len(res) != {{len .ResultTypes}} {
+		panic({{pkg "fmt"}}.Sprintf("interceptor of method {{.Name}} returns %d results instead of {{len .ResultTypes}}", len(res)))
+	}
+{{- $name := .Name}}{{$names := .ResultNames}}{{$nilable := .ResultNilable}}
+{{range $i, $typ := .ResultTypes}}
+	{{index $names $i}}, ok := res[{{$i}}].({{$typ}})
+	if !ok{{if index $nilable $i}} && res[{{$i}}] != nil{{end}} {
+		panic({{pkg "fmt"}}.Sprintf("interceptor of method {{$name}} returns %T result {{$i}} instead of %s", res[{{$i}}], {{printf "%q" $typ}}))
+	}
+{{- end}}
+
+	return {{.Results}}
+{{- end}}
 }
 {{end}}
 `
 
-var tmpl = template.Must(template.New("proxy").Parse(tmplText))
+var (
+	tmpl            = template.Must(template.New("proxy").Funcs(gen.TemplateFuncs).Parse(tmplText))
+	tmplSlog        = template.Must(template.New("proxy_slog").Funcs(gen.TemplateFuncs).Parse(tmplSlogText))
+	tmplInterceptor = template.Must(template.New("proxy_interceptor").Funcs(gen.TemplateFuncs).Parse(tmplInterceptorText))
+)
@@ -0,0 +1,28 @@
+package recovery
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+type config struct {
+	Log    bool
+	Sorted bool
+}
+
+func parseArgs(arguments []string, defaultValue config) (config, error) {
+	var cfg config
+
+	flagset := flag.NewFlagSet("", flag.ContinueOnError)
+
+	flagset.BoolVar(&cfg.Log, "log", defaultValue.Log, "log panics of methods without error result before re-panicking")
+	flagset.BoolVar(&cfg.Sorted, "sorted", defaultValue.Sorted, gen.SortedUsage)
+
+	if err := flagset.Parse(arguments); err != nil {
+		return config{}, fmt.Errorf("flagset: Parse: %w", err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,76 @@
+package recovery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/gen/analysis"
+)
+
+func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]gen.File, error) {
+	return gen.GenerateFiles(ctx, name, gp, func(buf *bytes.Buffer, gp []gen.Please) error {
+		return generate(buf, name, gp)
+	})
+}
+
+func generate(buf *bytes.Buffer, name gen.GeneratorName, gp []gen.Please) error {
+	imports := gen.NewImports(gp[0].TS.Pkg.Types, gp[0].Imports)
+	imports.Reserve("PanicError", "w", "v", "e", "err", "logger")
+
+	ifaces, err := analysis.InterfacesOf(gp, imports, "w", "v", "PanicError")
+	if err != nil {
+		return fmt.Errorf("analyze: %w", err)
+	}
+
+	if err := gen.CheckShared(name, gp, "PanicError"); err != nil {
+		return err
+	}
+
+	body := bytes.NewBuffer(nil)
+
+	if !gen.SharedDeclared(name, gp, nil) {
+		if err := imports.Execute(body, tmplPanicError, nil); err != nil {
+			return fmt.Errorf("execute template: %w", err)
+		}
+	}
+
+	for i, pls := range gp {
+		cfg, err := parseArgs(pls.Args, config{})
+		if err != nil {
+			return fmt.Errorf("parse command arguments: %w", err)
+		}
+
+		if cfg.Sorted {
+			ifaces[i].SortMethods()
+		}
+
+		if err := genRecover(body, imports, ifaces[i], cfg); err != nil {
+			return err
+		}
+	}
+
+	imports.Write(buf)
+	buf.Write(body.Bytes())
+
+	return nil
+}
+
+func genRecover(buf *bytes.Buffer, imports *gen.Imports, iface analysis.Interface, cfg config) error {
+	data := struct {
+		analysis.Decorator
+		Methods []analysis.DecoratedMethod
+		Log     bool
+	}{
+		Decorator: analysis.DecoratorOf("Recover", iface, imports.Qualifier),
+		Methods:   analysis.DecoratedMethodsOf(iface, imports.Qualifier),
+		Log:       cfg.Log,
+	}
+
+	if err := imports.Execute(buf, tmpl, data); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	return nil
+}
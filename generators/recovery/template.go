@@ -0,0 +1,79 @@
+package recovery
+
+import (
+	"text/template"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+const tmplPanicErrorText = `// PanicError is returned by the recovering wrappers if the wrapped method panics.
+type PanicError struct {
+	Method string
+	Value  any
+	Stack  []byte
+}
+
+func (e *PanicError) Error() string {
+	return {{pkg "fmt"}}.Sprintf("method %s panics: %v", e.Method, e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+`
+
+//nolint:lll
+const tmplText = `// *{{.ConcrName}} implements {{.InterfaceName}}.
+type {{.ConcrName}}{{.TypeParamsDecl}} struct {
+	v{{if .Log}}     {{end}} {{.InterfaceName}}{{.TypeParams}}
+{{- if .Log}}
+	logger interface{Log(string, ...any)}
+{{- end}}
+}
+
+func New{{.ConcrName}}{{.TypeParamsDecl}}(v {{.InterfaceName}}{{.TypeParams}}{{if .Log}}, logger interface{Log(string, ...any)}{{end}}) (*{{.ConcrName}}{{.TypeParams}}, error) {
+	if v == nil {
+		return nil, {{pkg "errors"}}.New("v is nil")
+	}
+{{- if .Log}}
+	if logger == nil {
+		return nil, {{pkg "errors"}}.New("logger is nil")
+	}
+{{- end}}
+	return &{{.ConcrName}}{{.TypeParams}}{
+		v:{{if .Log}}     {{end}} v,
+{{- if .Log}}
+		logger: logger,
+{{- end}}
+	}, nil
+}
+{{range .Methods}}
+{{.Doc}}func (w *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+{{- if .Err}}
+	defer func() {
+		if v := recover(); v != nil {
+			{{.Err}} = &PanicError{Method: "{{.Name}}", Value: v, Stack: {{pkg "runtime/debug"}}.Stack()}
+		}
+	}()
+
+{{else if $.Log}}
+	defer func() {
+		if v := recover(); v != nil {
+			w.logger.Log("Recovered panic in {{.Name}}", "value", v, "stack", string({{pkg "runtime/debug"}}.Stack()))
+			panic(v)
+		}
+	}()
+
+{{else}}
+{{end}}	{{if .Ret}}return {{end}}w.v.{{.Name}}({{.Args}})
+}
+{{end}}
+`
+
+var (
+	tmpl           = template.Must(template.New("recover").Funcs(gen.TemplateFuncs).Parse(tmplText))
+	tmplPanicError = template.Must(template.New("panic_error").Funcs(gen.TemplateFuncs).Parse(tmplPanicErrorText))
+)
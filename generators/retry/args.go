@@ -0,0 +1,83 @@
+package retry
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+type config struct {
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	Skip       gen.ArgSet
+	Methods    gen.ArgSet
+	Sorted     bool
+}
+
+func parseArgs(arguments []string, defaultValue config) (config, error) {
+	var cfg config
+
+	flagset := flag.NewFlagSet("", flag.ContinueOnError)
+
+	flagset.IntVar(&cfg.Attempts, "attempts", defaultValue.Attempts, "default maximum number of calls")
+	flagset.DurationVar(&cfg.Backoff, "backoff", defaultValue.Backoff, "default delay before the first retry")
+	flagset.DurationVar(&cfg.MaxBackoff, "max-backoff", defaultValue.MaxBackoff, "default maximum delay between calls")
+	flagset.Var(&cfg.Skip, "skip", "comma separated list of methods which are not retried")
+	flagset.Var(&cfg.Methods, "methods",
+		"comma separated list of method:attempts pairs setting the default maximum number of calls per method, "+
+			"positive RetryPolicy.MaxAttempts overrides them")
+	flagset.BoolVar(&cfg.Sorted, "sorted", defaultValue.Sorted, gen.SortedUsage)
+
+	if err := flagset.Parse(arguments); err != nil {
+		return config{}, fmt.Errorf("flagset: Parse: %w", err)
+	}
+
+	if cfg.Attempts < 1 {
+		return config{}, fmt.Errorf("attempts %d must be positive", cfg.Attempts)
+	}
+
+	if cfg.Backoff < 0 || cfg.MaxBackoff < 0 {
+		return config{}, errors.New("backoff must not be negative")
+	}
+
+	if cfg.MaxBackoff < cfg.Backoff {
+		return config{}, fmt.Errorf("max backoff %s is less than backoff %s", cfg.MaxBackoff, cfg.Backoff)
+	}
+
+	if _, err := cfg.methodAttempts(); err != nil {
+		return config{}, err
+	}
+
+	return cfg, nil
+}
+
+// methodAttempts returns maximum number of calls by method name.
+func (cfg config) methodAttempts() (map[string]int, error) {
+	attempts := make(map[string]int, len(cfg.Methods))
+
+	for _, m := range cfg.Methods {
+		name, value, ok := strings.Cut(m, ":")
+		if !ok {
+			return nil, fmt.Errorf("method %q: expected method:attempts pair", m)
+		}
+
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("method %q: %w", name, err)
+		}
+
+		if n < 1 {
+			return nil, fmt.Errorf("method %q: attempts %d must be positive", name, n)
+		}
+
+		attempts[name] = n
+	}
+
+	return attempts, nil
+}
@@ -0,0 +1,138 @@
+package retry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/gen/analysis"
+)
+
+func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]gen.File, error) {
+	return gen.GenerateFiles(ctx, name, gp, func(buf *bytes.Buffer, gp []gen.Please) error {
+		return generate(buf, name, gp)
+	})
+}
+
+type methInfo struct {
+	analysis.DecoratedMethod
+	// Attempts is the method's default maximum number of calls if positive.
+	Attempts int
+	Skip     bool
+}
+
+func generate(buf *bytes.Buffer, name gen.GeneratorName, gp []gen.Please) error {
+	imports := gen.NewImports(gp[0].TS.Pkg.Types, gp[0].Imports)
+	imports.Reserve(
+		"RetryPolicy", "p", "d", "i", "ctx", "retry", "err", "timer", "w", "v",
+		"policy", "maxAttempts", "attempt", "waitErr",
+	)
+
+	ifaces, err := analysis.InterfacesOf(gp, imports, "w", "maxAttempts", "attempt", "waitErr")
+	if err != nil {
+		return fmt.Errorf("analyze: %w", err)
+	}
+
+	if err := gen.CheckShared(name, gp, "RetryPolicy"); err != nil {
+		return err
+	}
+
+	body := bytes.NewBuffer(nil)
+
+	if !gen.SharedDeclared(name, gp, nil) {
+		if err := imports.Execute(body, tmplPolicy, nil); err != nil {
+			return fmt.Errorf("execute template: %w", err)
+		}
+	}
+
+	for i, pls := range gp {
+		cfg, err := parseArgs(pls.Args, config{
+			Attempts:   3,
+			Backoff:    100 * time.Millisecond,
+			MaxBackoff: 10 * time.Second,
+		})
+		if err != nil {
+			return fmt.Errorf("parse command arguments: %w", err)
+		}
+
+		if cfg.Sorted {
+			ifaces[i].SortMethods()
+		}
+
+		methInfos, err := analyze(ifaces[i], imports, cfg)
+		if err != nil {
+			return fmt.Errorf("analyze: %w", err)
+		}
+
+		if err := genRetry(body, imports, ifaces[i], methInfos, cfg); err != nil {
+			return err
+		}
+	}
+
+	imports.Write(buf)
+	buf.Write(body.Bytes())
+
+	return nil
+}
+
+func analyze(iface analysis.Interface, imports *gen.Imports, cfg config) ([]methInfo, error) {
+	qf := imports.Qualifier
+
+	attempts, err := cfg.methodAttempts()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", iface.Pos, err)
+	}
+
+	skip := make(map[string]bool, len(cfg.Skip))
+	for _, name := range cfg.Skip {
+		skip[name] = true
+	}
+
+	if err := iface.CheckMethods(slices.Sorted(maps.Keys(attempts))...); err != nil {
+		return nil, err
+	}
+
+	if err := iface.CheckMethods(cfg.Skip...); err != nil {
+		return nil, err
+	}
+
+	methInfos := make([]methInfo, 0, len(iface.Methods))
+
+	for _, meth := range iface.Methods {
+		methInfos = append(methInfos, methInfo{
+			DecoratedMethod: analysis.DecoratedMethodOf(meth, qf),
+			Attempts:        attempts[meth.Name],
+			Skip:            skip[meth.Name],
+		})
+	}
+
+	return methInfos, nil
+}
+
+func genRetry(
+	buf *bytes.Buffer, imports *gen.Imports, iface analysis.Interface, methInfos []methInfo, cfg config,
+) error {
+	data := struct {
+		analysis.Decorator
+		Methods    []methInfo
+		Attempts   int
+		Backoff    string
+		MaxBackoff string
+	}{
+		Decorator:  analysis.DecoratorOf("Retry", iface, imports.Qualifier),
+		Methods:    methInfos,
+		Attempts:   cfg.Attempts,
+		Backoff:    imports.Render(gen.Duration(cfg.Backoff)),
+		MaxBackoff: imports.Render(gen.Duration(cfg.MaxBackoff)),
+	}
+
+	if err := imports.Execute(buf, tmpl, data); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	return nil
+}
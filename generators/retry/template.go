@@ -0,0 +1,114 @@
+package retry
+
+import (
+	"text/template"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+const tmplPolicyText = `// RetryPolicy configures retries of the wrapped methods returning error.
+// Zero fields are set to the defaults of the wrapper.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls of every method including the first one.
+	// If it is positive, it overrides the wrapper's defaults including the per-method ones.
+	// If it is zero, the wrapper's default of the method is used.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, it is doubled for each next retry.
+	Backoff {{pkg "time"}}.Duration
+	// MaxBackoff limits the delay between calls.
+	MaxBackoff {{pkg "time"}}.Duration
+	// Retryable reports whether the call failed with err can be retried.
+	// All errors are retried if Retryable is nil.
+	Retryable func(err error) bool
+}
+
+// delay returns the jittered delay before the retry.
+func (p RetryPolicy) delay(retry int) {{pkg "time"}}.Duration {
+	d := p.Backoff
+	for i := 1; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	d = min(d, p.MaxBackoff)
+	if d <= 0 {
+		return 0
+	}
+
+	return d/2 + {{pkg "math/rand/v2"}}.N(d/2+1)
+}
+
+// wait blocks for the delay before the retry or until ctx is done.
+func (p RetryPolicy) wait(ctx {{pkg "context"}}.Context, retry int) error {
+	timer := {{pkg "time"}}.NewTimer(p.delay(retry))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+`
+
+//nolint:lll
+const tmplText = `// *{{.ConcrName}} implements {{.InterfaceName}}.
+type {{.ConcrName}}{{.TypeParamsDecl}} struct {
+	v      {{.InterfaceName}}{{.TypeParams}}
+	policy RetryPolicy
+}
+
+// New{{.ConcrName}} returns a new *{{.ConcrName}} retrying calls of v according to policy.
+func New{{.ConcrName}}{{.TypeParamsDecl}}(v {{.InterfaceName}}{{.TypeParams}}, policy RetryPolicy) (*{{.ConcrName}}{{.TypeParams}}, error) {
+	if v == nil {
+		return nil, {{pkg "errors"}}.New("v is nil")
+	}
+
+	if policy.Backoff <= 0 {
+		policy.Backoff = {{.Backoff}}
+	}
+
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = {{.MaxBackoff}}
+	}
+
+	return &{{.ConcrName}}{{.TypeParams}}{
+		v:      v,
+		policy: policy,
+	}, nil
+}
+{{range .Methods}}
+{{.Doc}}func (w *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+{{- if and .Err (not .Skip)}}
+	maxAttempts := w.policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = {{if .Attempts}}{{.Attempts}}{{else}}{{$.Attempts}}{{end}}
+	}
+
+	for attempt := 1; ; attempt++ {
+		{{.Results}} = w.v.{{.Name}}({{.Args}})
+		if {{.Err}} == nil || attempt >= maxAttempts || !w.policy.retryable({{.Err}}) {
+			return {{.Results}}
+		}
+
+		if waitErr := w.policy.wait({{if .Ctx}}{{.Ctx}}{{else}}{{pkg "context"}}.Background(){{end}}, attempt); waitErr != nil {
+			{{.Err}} = {{pkg "errors"}}.Join({{.Err}}, waitErr)
+			return {{.Results}}
+		}
+	}
+{{- else}}
+	{{if .Ret}}return {{end}}w.v.{{.Name}}({{.Args}})
+{{- end}}
+}
+{{end}}
+`
+
+var (
+	tmpl       = template.Must(template.New("retry").Funcs(gen.TemplateFuncs).Parse(tmplText))
+	tmplPolicy = template.Must(template.New("policy").Funcs(gen.TemplateFuncs).Parse(tmplPolicyText))
+)
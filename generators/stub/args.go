@@ -0,0 +1,41 @@
+package stub
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+// Behavior modes of the stub methods.
+const (
+	modePanic = "panic"
+	modeZero  = "zero"
+	modeError = "error"
+)
+
+type config struct {
+	Mode   string
+	Sorted bool
+}
+
+func parseArgs(arguments []string, defaultValue config) (config, error) {
+	var cfg config
+
+	flagset := flag.NewFlagSet("", flag.ContinueOnError)
+
+	flagset.StringVar(&cfg.Mode, "mode", defaultValue.Mode, "stub methods behavior: panic, zero or error")
+	flagset.BoolVar(&cfg.Sorted, "sorted", defaultValue.Sorted, gen.SortedUsage)
+
+	if err := flagset.Parse(arguments); err != nil {
+		return config{}, fmt.Errorf("flagset: Parse: %w", err)
+	}
+
+	switch cfg.Mode {
+	case modePanic, modeZero, modeError:
+	default:
+		return config{}, fmt.Errorf("unknown mode %q", cfg.Mode)
+	}
+
+	return cfg, nil
+}
@@ -1,234 +1,126 @@
 package stub
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"go/ast"
-	"go/types"
-	"maps"
 	"slices"
-	"strings"
 
 	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/gen/analysis"
 )
 
 func Generate(ctx context.Context, name gen.GeneratorName, gp []gen.Please) ([]gen.File, error) {
-	var files []gen.File
-
-	buf := bytes.NewBuffer(nil)
-
-	for filename, gp := range gen.IterateFiles(gp) {
-		buf.Reset()
-		buf.WriteString(gp[0].FormatDoNotEditHeader(name))
-		buf.WriteString(gp[0].FormatPkg())
-
-		if err := generate(buf, gp); err != nil {
-			return nil, fmt.Errorf("generate: %w", err)
-		}
-
-		files = append(files, gen.File{
-			Name: gp[0].FormatGeneratorFileName(name, strings.HasSuffix(filename, "_test.go")),
-			Data: bytes.Clone(buf.Bytes()),
-		})
-
-		select {
-		case <-ctx.Done():
-			return nil, fmt.Errorf("context is closed: %w", ctx.Err())
-		default:
-		}
-	}
-
-	return files, nil
+	return gen.GenerateSources(ctx, name, gp, func(src *gen.Source, gp []gen.Please, _ string) error {
+		return generate(src, name, gp)
+	})
 }
 
-type methInfo struct {
-	name string
-	sig  string
-}
+func generate(src *gen.Source, name gen.GeneratorName, gp []gen.Please) error {
+	src.Imports.Reserve("ErrNotImplemented")
 
-type ifaceInfo struct {
-	name           string
-	object         types.Object
-	methInfos      []methInfo
-	typeParamsDecl string
-	typeParams     string
-}
+	ifaces, err := analysis.InterfacesOf(gp, src.Imports, "ErrNotImplemented")
+	if err != nil {
+		return fmt.Errorf("analyze: %w", err)
+	}
 
-func generate(buf *bytes.Buffer, gp []gen.Please) error {
-	usedImports := map[gen.PkgPath]gen.PkgName{}
-	infos := make([]ifaceInfo, 0, len(gp))
+	modes := make([]string, 0, len(gp))
 
-	for _, pls := range gp {
-		info, err := analyze(pls, usedImports)
+	for i, pls := range gp {
+		cfg, err := parseArgs(pls.Args, config{
+			Mode: modePanic,
+		})
 		if err != nil {
-			return fmt.Errorf("analyze: %w", err)
+			return fmt.Errorf("parse command arguments: %w", err)
 		}
 
-		infos = append(infos, info)
-	}
-
-	if len(usedImports) > 0 {
-		genImports(buf, usedImports)
-	}
-
-	for _, inf := range infos {
-		genStubIface(buf, inf)
-	}
-
-	return nil
-}
-
-func analyze(pls gen.Please, usedImports map[gen.PkgPath]gen.PkgName) (ifaceInfo, error) {
-	ifacename := pls.TS.Spec.Name.Name
-	position := pls.TS.Pkg.Fset.Position(pls.TS.Spec.Pos())
-
-	_, ok := pls.TS.Spec.Type.(*ast.InterfaceType)
-	if !ok {
-		return ifaceInfo{}, fmt.Errorf("%s: type %q must be an interface", position, ifacename)
-	}
+		if cfg.Sorted {
+			ifaces[i].SortMethods()
+		}
 
-	object := pls.TS.Pkg.Types.Scope().Lookup(ifacename)
-	if object == nil {
-		return ifaceInfo{}, fmt.Errorf("%s: object %s not found", position, ifacename)
+		modes = append(modes, cfg.Mode)
 	}
 
-	if _, ok := object.(*types.TypeName); !ok {
-		return ifaceInfo{}, fmt.Errorf("%v is not a named type", object)
+	if err := gen.CheckShared(name, gp, "ErrNotImplemented"); err != nil {
+		return err
 	}
 
-	typ, ok := object.Type().(*types.Named)
-	if !ok {
-		return ifaceInfo{}, fmt.Errorf("unexpected type %T", object.Type())
+	if slices.Contains(modes, modeError) && !gen.SharedDeclared(name, gp, errorMode) {
+		src.Decls = append(src.Decls, gen.VarDecl{
+			Doc:   "ErrNotImplemented is returned by the stub methods which are not implemented.",
+			Name:  "ErrNotImplemented",
+			Value: gen.Call(gen.Qual("errors", "New"), gen.Lit("not implemented")),
+		})
 	}
 
-	pkgAliasFn := alias(pls.TS.Pkg.Types, pls.Imports, usedImports)
-
-	typeParamsDecl, typeParams := typeParams(typ, pkgAliasFn)
-
-	mset := types.NewMethodSet(object.Type())
-
-	methInfos := make([]methInfo, 0, mset.Len())
-
-	for i := range mset.Len() {
-		meth := mset.At(i).Obj()
-		sig := types.TypeString(meth.Type(), pkgAliasFn)
-
-		methInfos = append(methInfos, methInfo{
-			name: meth.Name(),
-			sig:  strings.TrimPrefix(sig, "func"),
-		})
+	for i, iface := range ifaces {
+		src.Decls = append(src.Decls, stubDecls(iface, modes[i])...)
 	}
 
-	return ifaceInfo{
-		name:           ifacename,
-		object:         object,
-		methInfos:      methInfos,
-		typeParamsDecl: typeParamsDecl,
-		typeParams:     typeParams,
-	}, nil
+	return nil
 }
 
-func alias(
-	pkg *types.Package,
-	imports map[gen.PkgPath]gen.PkgName,
-	usedImports map[gen.PkgPath]gen.PkgName,
-) types.Qualifier {
-	return func(p *types.Package) string {
-		if pkg == p {
-			// local imports are unqualified.
-			return ""
-		}
-
-		path := gen.PkgPath(p.Path())
-		alias := imports[path]
+// errorMode reports whether the stub directive's arguments set the error mode.
+func errorMode(args []string) bool {
+	cfg, err := parseArgs(args, config{Mode: modePanic})
 
-		// Populate imports used by generated types.
-		// Include empty alias too.
-		if usedImports != nil {
-			usedImports[path] = alias
-		}
-
-		if alias == "" {
-			return p.Name()
-		}
-
-		return string(alias)
-	}
+	return err == nil && cfg.Mode == modeError
 }
 
-func typeParams(typ *types.Named, pkgAliasFn types.Qualifier) (typeParamsDecl string, typeParams string) {
-	if typ.TypeParams().Len() > 0 {
-		typeParamsDecl = "["
-		typeParams = "["
-
-		for i := range typ.TypeParams().Len() {
-			param := typ.TypeParams().At(i)
-			constraintName := types.TypeString(param.Constraint(), pkgAliasFn)
-
-			if i == 0 {
-				typeParamsDecl += param.String() + " " + constraintName
-				typeParams += param.String()
-			} else {
-				typeParamsDecl += ", " + param.String() + " " + constraintName
-				typeParams += ", " + param.String()
-			}
-		}
+// stubDecls returns the declarations of the stub type and its methods.
+func stubDecls(iface analysis.Interface, mode string) []gen.Code {
+	concrname := analysis.ConcreteName("Unimplemented", iface.Name)
 
-		typeParamsDecl += "]"
-		typeParams += "]"
-	}
-
-	return typeParamsDecl, typeParams
-}
+	typeParams := iface.TypeParamList()
 
-func genImports(buf *bytes.Buffer, usedImports map[gen.PkgPath]gen.PkgName) {
-	pkgs := slices.Sorted(maps.Keys(usedImports))
+	decls := []gen.Code{
+		gen.TypeDecl{
+			Doc:        "*" + concrname + " implements " + iface.Name + ".",
+			Name:       concrname,
+			TypeParams: typeParams,
+			Type:       gen.StructType(),
+		},
+	}
 
-	buf.WriteString("import (\n")
+	recv := &gen.Param{Type: gen.Ptr(gen.Instance(concrname, typeParams))}
 
-	for _, pkg := range pkgs {
-		buf.WriteByte('\t')
+	for _, meth := range iface.Methods {
+		fn := gen.FuncOf(meth.Name, meth.Signature)
+		fn.Doc = meth.DocText()
+		fn.Recv = recv
 
-		alias := usedImports[pkg]
-		if alias != "" {
-			buf.WriteString(string(alias))
-			buf.WriteByte(' ')
+		switch mode {
+		case modeZero, modeError:
+			fn = fn.NamedResults()
+			fn.Body = zeroBody(fn, meth, mode)
+		default:
+			fn.Body = []gen.Code{
+				gen.Line(gen.Call(gen.Ident("panic"), gen.Lit("method "+meth.Name+" is not implemented!"))),
+			}
 		}
 
-		buf.WriteByte('"')
-		buf.WriteString(string(pkg))
-		buf.WriteString("\"\n")
+		decls = append(decls, fn)
 	}
 
-	buf.WriteString(")\n\n")
+	return decls
 }
 
-func genStubIface(buf *bytes.Buffer, inf ifaceInfo) {
-	var concrname string
-
-	const unimplemented = "Unimplemented"
-
-	startChar := string([]rune(inf.name)[0])
+// zeroBody returns the body of the method returning zero values.
+// At the error mode the last error result is ErrNotImplemented wrapped with the method name.
+func zeroBody(fn gen.FuncDecl, meth analysis.Method, mode string) []gen.Code {
+	results := fn.ResultNames()
+
+	switch {
+	case mode == modeError && meth.Err():
+		results[len(results)-1] = gen.Call(
+			gen.Qual("fmt", "Errorf"),
+			gen.Lit("method "+meth.Name+": %w"),
+			gen.Ident("ErrNotImplemented"),
+		)
 
-	if upper := strings.ToUpper(startChar); startChar != upper {
-		if len(inf.name) > 1 {
-			concrname = unimplemented + upper + inf.name[1:]
-		} else {
-			concrname = unimplemented + upper
-		}
-	} else {
-		concrname = unimplemented + inf.name
+		return []gen.Code{gen.Return(results...)}
+	case len(results) > 0:
+		return []gen.Code{gen.Return()}
 	}
 
-	// fmt.Fprintf(buf, "var _ %s = (*%s)(nil)\n\n", inf.name, concrname)
-	fmt.Fprintf(buf, "// *%s implements %s.\n", concrname, inf.name)
-	fmt.Fprintf(buf, "type %s%s struct{}\n\n", concrname, inf.typeParamsDecl)
-
-	for _, minf := range inf.methInfos {
-		fmt.Fprintf(buf,
-			"func (*%s%s) %s%s {\n\tpanic(\"method %s is not implemented!\")\n}\n\n",
-			concrname, inf.typeParams, minf.name, minf.sig, minf.name,
-		)
-	}
+	return nil
 }
@@ -0,0 +1,29 @@
+package trace
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+type config struct {
+	Background bool
+	Sorted     bool
+}
+
+func parseArgs(arguments []string, defaultValue config) (config, error) {
+	var cfg config
+
+	flagset := flag.NewFlagSet("", flag.ContinueOnError)
+
+	flagset.BoolVar(&cfg.Background, "background", defaultValue.Background,
+		"trace methods without context parameter using context.Background")
+	flagset.BoolVar(&cfg.Sorted, "sorted", defaultValue.Sorted, gen.SortedUsage)
+
+	if err := flagset.Parse(arguments); err != nil {
+		return config{}, fmt.Errorf("flagset: Parse: %w", err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,73 @@
+package trace
+
+import (
+	"text/template"
+
+	"github.com/WinPooh32/genpls/gen"
+)
+
+const tmplTracerText = `// Tracer starts spans of the calls of the methods wrapped by the trace wrappers.
+type Tracer interface {
+	// Start starts a span named name and returns the context carrying the span.
+	Start(ctx {{pkg "context"}}.Context, name string) ({{pkg "context"}}.Context, TraceSpan)
+}
+
+// TraceSpan is a span started by Tracer.
+type TraceSpan interface {
+	// RecordError records the error returned by the call.
+	RecordError(err error)
+	// End ends the span.
+	End()
+}
+
+`
+
+//nolint:lll
+const tmplText = `// *{{.ConcrName}} implements {{.InterfaceName}}.
+type {{.ConcrName}}{{.TypeParamsDecl}} struct {
+	v      {{.InterfaceName}}{{.TypeParams}}
+	tracer Tracer
+}
+
+// New{{.ConcrName}} returns a new *{{.ConcrName}} tracing calls of v with tracer.
+func New{{.ConcrName}}{{.TypeParamsDecl}}(v {{.InterfaceName}}{{.TypeParams}}, tracer Tracer) (*{{.ConcrName}}{{.TypeParams}}, error) {
+	if v == nil {
+		return nil, {{pkg "errors"}}.New("v is nil")
+	}
+
+	if tracer == nil {
+		return nil, {{pkg "errors"}}.New("tracer is nil")
+	}
+
+	return &{{.ConcrName}}{{.TypeParams}}{
+		v:      v,
+		tracer: tracer,
+	}, nil
+}
+{{range .Methods}}
+{{.Doc}}func (w *{{$.ConcrName}}{{$.TypeParams}}) {{.Name}}{{.Sig}} {
+{{- if or .Ctx $.Background}}
+	{{if .Ctx}}{{.Ctx}}{{else}}_{{end}}, span := w.tracer.Start({{if .Ctx}}{{.Ctx}}{{else}}{{pkg "context"}}.Background(){{end}}, "{{$.InterfaceName}}.{{.Name}}")
+	defer span.End()
+
+	{{if .Ret}}{{.Results}} = {{end}}w.v.{{.Name}}({{.Args}})
+{{- if .Err}}
+	if {{.Err}} != nil {
+		span.RecordError({{.Err}})
+	}
+{{- end}}
+{{- if .Ret}}
+
+	return {{.Results}}
+{{- end}}
+{{- else}}
+	{{if .Ret}}return {{end}}w.v.{{.Name}}({{.Args}})
+{{- end}}
+}
+{{end}}
+`
+
+var (
+	tmpl       = template.Must(template.New("trace").Funcs(gen.TemplateFuncs).Parse(tmplText))
+	tmplTracer = template.Must(template.New("tracer").Funcs(gen.TemplateFuncs).Parse(tmplTracerText))
+)
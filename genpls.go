@@ -162,7 +162,7 @@ func (gw *genWorker) scan(pkg *packages.Package) map[string][]gen.Please {
 
 	in := inspector.New(syntax)
 
-	imports := gw.imports(in)
+	imports := gw.imports(pkg, syntax)
 
 	for ts := range gw.typeSpecs(pkg, in) {
 		typs[ts.Spec.Name.Name] = &ts
@@ -187,7 +187,9 @@ func (gw *genWorker) scan(pkg *packages.Package) map[string][]gen.Please {
 	cmds := map[string][]gen.Please{}
 
 	for _, ts := range typs {
-		ts.AddCMD(cmds, imports, commands(ts, gw.gens))
+		filename := pkg.Fset.Position(ts.Spec.Pos()).Filename
+
+		ts.AddCMD(cmds, imports[filename], commands(ts, gw.gens))
 	}
 
 	return cmds
@@ -229,40 +231,36 @@ func (gw *genWorker) sendFile(ctx context.Context, file gen.File) error {
 	return nil
 }
 
-var funcImportSpecFilter = []ast.Node{
-	new(ast.ImportSpec),
-}
+// imports returns the maps of the files imports by the file names.
+// The map has a key as a package path and value as an alias of the package name.
+func (gw *genWorker) imports(pkg *packages.Package, syntax []*ast.File) map[string]map[gen.PkgPath]gen.PkgName {
+	files := make(map[string]map[gen.PkgPath]gen.PkgName, len(syntax))
 
-// imports returns the map with a key as a package path and value as an alias of the package name.
-func (gw *genWorker) imports(in *inspector.Inspector) map[gen.PkgPath]gen.PkgName {
-	m := map[gen.PkgPath]gen.PkgName{}
+	for _, file := range syntax {
+		m := map[gen.PkgPath]gen.PkgName{}
 
-	in.Nodes(funcImportSpecFilter, func(n ast.Node, _ bool) (proceed bool) {
-		spec, ok := n.(*ast.ImportSpec)
-		if !ok {
-			return true
-		}
+		for _, spec := range file.Imports {
+			if spec.Name == nil {
+				continue
+			}
 
-		if spec.Name == nil {
-			return true
-		}
+			switch spec.Name.Name {
+			case "_", ".":
+				continue
+			}
 
-		switch spec.Name.Name {
-		case "_", ".":
-			return true
-		}
+			pkgPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				panic("failed to unquote package import path " + spec.Path.Value)
+			}
 
-		pkgPath, err := strconv.Unquote(spec.Path.Value)
-		if err != nil {
-			panic("failed to unquote package import path " + spec.Path.Value)
+			m[gen.PkgPath(pkgPath)] = gen.PkgName(spec.Name.Name)
 		}
 
-		m[gen.PkgPath(pkgPath)] = gen.PkgName(spec.Name.Name)
-
-		return true
-	})
+		files[pkg.Fset.Position(file.Pos()).Filename] = m
+	}
 
-	return m
+	return files
 }
 
 var typeSpecsFilter = []ast.Node{
@@ -364,33 +362,27 @@ func isTestPackage(pkg *packages.Package) bool {
 	return false
 }
 
+// inspectRecvName returns the receiver's base type name.
+// Pointer, parenthesized and generic receivers like (*T[K, V]) are resolved to T.
 func inspectRecvName(recv *ast.FieldList) (name string) {
-	node := recv.List[0].Type
+	expr := recv.List[0].Type
 
-	ast.Inspect(node, func(ast.Node) bool {
-		switch node := node.(type) {
+	for {
+		switch node := expr.(type) {
 		case *ast.Ident:
-			name = node.Name
-
+			return node.Name
 		case *ast.StarExpr:
-			ast.Inspect(node, func(ast.Node) bool {
-				ident, ok := node.X.(*ast.Ident)
-				if !ok {
-					return true
-				}
-
-				name = ident.Name
-
-				return false
-			})
-
-			return false
+			expr = node.X
+		case *ast.ParenExpr:
+			expr = node.X
+		case *ast.IndexExpr:
+			expr = node.X
+		case *ast.IndexListExpr:
+			expr = node.X
+		default:
+			return ""
 		}
-
-		return true
-	})
-
-	return name
+	}
 }
 
 func commands(ts *gen.TypeSpec, gens map[gen.GeneratorName]gen.Func) iter.Seq[gen.Command] {
@@ -400,7 +392,12 @@ func commands(ts *gen.TypeSpec, gens map[gen.GeneratorName]gen.Func) iter.Seq[ge
 			textOnly = strings.TrimSpace(textOnly)
 
 			name, args, _ := strings.Cut(textOnly, " ")
-			name = strings.TrimPrefix(name, gen.CmdPrefix)
+
+			// Doc lines starting with a generator name like "// stub is ..." are not directives.
+			name, ok := strings.CutPrefix(name, gen.CmdPrefix)
+			if !ok {
+				continue
+			}
 
 			genf, ok := gens[gen.GeneratorName(name)]
 			if !ok {
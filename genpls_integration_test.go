@@ -7,7 +7,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"go/ast"
+	"go/format"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
@@ -15,7 +18,19 @@ import (
 
 	"github.com/WinPooh32/genpls"
 	"github.com/WinPooh32/genpls/gen"
+	"github.com/WinPooh32/genpls/generators/accessors"
+	"github.com/WinPooh32/genpls/generators/breaker"
+	"github.com/WinPooh32/genpls/generators/builder"
+	"github.com/WinPooh32/genpls/generators/cache"
+	"github.com/WinPooh32/genpls/generators/fake"
+	"github.com/WinPooh32/genpls/generators/metrics"
+	"github.com/WinPooh32/genpls/generators/mock"
+	"github.com/WinPooh32/genpls/generators/options"
+	"github.com/WinPooh32/genpls/generators/proxy"
+	"github.com/WinPooh32/genpls/generators/recovery"
+	"github.com/WinPooh32/genpls/generators/retry"
 	"github.com/WinPooh32/genpls/generators/stub"
+	"github.com/WinPooh32/genpls/generators/trace"
 	"github.com/WinPooh32/genpls/opt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -172,6 +187,41 @@ var genmap1 = map[gen.GeneratorName]gen.Func{
 	},
 }
 
+// genmapGolden are the generators which output is committed to the parsing testdata.
+var genmapGolden = map[gen.GeneratorName]gen.Func{
+	"stub":      stub.Generate,
+	"proxy":     proxy.Generate,
+	"mock":      mock.Generate,
+	"fake":      fake.Generate,
+	"recover":   recovery.Generate,
+	"retry":     retry.Generate,
+	"breaker":   breaker.Generate,
+	"metrics":   metrics.Generate,
+	"trace":     trace.Generate,
+	"cache":     cache.Generate,
+	"builder":   builder.Generate,
+	"options":   options.Generate,
+	"accessors": accessors.Generate,
+}
+
+// goldenFiles returns the names of the generated files committed to the directory.
+func goldenFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	var names []string
+
+	err := filepath.WalkDir(dir, func(path string, _ os.DirEntry, err error) error {
+		if strings.HasSuffix(path, "_gen.go") || strings.HasSuffix(path, "_gen_test.go") {
+			names = append(names, path)
+		}
+
+		return err
+	})
+	require.NoError(t, err)
+
+	return names
+}
+
 func TestGenerator_Generate(t *testing.T) {
 	t.Parallel()
 
@@ -186,6 +236,8 @@ func TestGenerator_Generate(t *testing.T) {
 		args     args
 		want     []opt.Result[gen.File]
 		wantJSON bool
+		// golden compares the generated files with the committed ones.
+		golden bool
 	}{
 		{
 			name: "simple",
@@ -216,6 +268,12 @@ func TestGenerator_Generate(t *testing.T) {
 			},
 			wantJSON: false,
 		},
+		{
+			name:   "golden",
+			gen:    mustLoad(t, "internal/_testdata/parsing", "./..."),
+			args:   args{jobs: 1, gens: genmapGolden},
+			golden: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -236,6 +294,29 @@ func TestGenerator_Generate(t *testing.T) {
 			slices.SortFunc(tt.want, f)
 			slices.SortFunc(gotResults, f)
 
+			if tt.golden {
+				dir, err := filepath.Abs("internal/_testdata/parsing")
+				require.NoError(t, err)
+
+				names := make([]string, 0, len(gotResults))
+
+				for _, res := range gotResults {
+					want, err := os.ReadFile(res.Ok.Name)
+					require.NoError(t, err)
+					assert.Equal(t, string(want), string(res.Ok.Data), res.Ok.Name)
+
+					formatted, err := format.Source(res.Ok.Data)
+					require.NoError(t, err, res.Ok.Name)
+					assert.Equal(t, string(formatted), string(res.Ok.Data), "%s is not gofmt-ed", res.Ok.Name)
+
+					names = append(names, res.Ok.Name)
+				}
+
+				assert.ElementsMatch(t, goldenFiles(t, dir), names)
+
+				return
+			}
+
 			for i := range tt.want {
 				wantRes := tt.want[i]
 				gotRes := gotResults[i]
@@ -255,3 +336,14 @@ func TestGenerator_Generate(t *testing.T) {
 		})
 	}
 }
+
+// TestGenerator_Fixtures runs tests of the generated code in the parsing testdata module.
+func TestGenerator_Fixtures(t *testing.T) {
+	t.Parallel()
+
+	cmd := exec.Command("go", "test", "-count=1", "./...")
+	cmd.Dir = "internal/_testdata/parsing"
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
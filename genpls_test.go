@@ -0,0 +1,30 @@
+package genpls
+
+import (
+	"go/ast"
+	"testing"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommands(t *testing.T) {
+	t.Parallel()
+
+	ts := &gen.TypeSpec{
+		Doc: &ast.CommentGroup{List: []*ast.Comment{
+			{Text: "// stub is the generator name starting the doc line."},
+			{Text: "//"},
+			{Text: "//genpls:stub -mode=zero"},
+			{Text: "//genpls:unknown"},
+		}},
+	}
+
+	var got []gen.Command
+
+	for cmd := range commands(ts, map[gen.GeneratorName]gen.Func{"stub": nil}) {
+		got = append(got, cmd)
+	}
+
+	assert.Equal(t, []gen.Command{{Name: "stub", Args: []string{"-mode=zero"}}}, got)
+}
@@ -0,0 +1,108 @@
+// Code generated by "genpls:accessors"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	io_1 "io"
+)
+
+// GetID returns ID or the zero value if m is nil.
+//
+// ID identifies the message.
+func (m *Message) GetID() int64 {
+	if m == nil {
+		return 0
+	}
+
+	return m.ID
+}
+
+// GetPayload returns Payload or the zero value if m is nil.
+func (m *Message) GetPayload() []byte {
+	if m == nil {
+		return nil
+	}
+
+	return m.Payload
+}
+
+// SetPayload sets Payload.
+func (m *Message) SetPayload(v []byte) {
+	m.Payload = v
+}
+
+// GetHeader returns Header or the zero value if m is nil.
+func (m *Message) GetHeader() map[string]string {
+	if m == nil {
+		return nil
+	}
+
+	return m.Header
+}
+
+// GetMeta returns Meta or the zero value if m is nil.
+func (m *Message) GetMeta() S1 {
+	if m == nil {
+		return S1{}
+	}
+
+	return m.Meta
+}
+
+// GetParent returns Parent or the zero value if m is nil.
+func (m *Message) GetParent() *Message {
+	if m == nil {
+		return nil
+	}
+
+	return m.Parent
+}
+
+// GetReader returns Reader or the zero value if m is nil.
+func (m *Message) GetReader() io_1.Reader {
+	if m == nil {
+		return nil
+	}
+
+	return m.Reader
+}
+
+// getVersion returns version or the zero value if m is nil.
+//
+// version is accessed by the unexported accessors.
+func (m *Message) getVersion() uint32 {
+	if m == nil {
+		return 0
+	}
+
+	return m.version
+}
+
+// setVersion sets version.
+func (m *Message) setVersion(v uint32) {
+	m.version = v
+}
+
+// GetValue returns Value or the zero value if n is nil.
+func (n *Node[T]) GetValue() T {
+	if n == nil {
+		return *new(T)
+	}
+
+	return n.Value
+}
+
+// getNext returns next or the zero value if n is nil.
+func (n *Node[T]) getNext() *Node[T] {
+	if n == nil {
+		return nil
+	}
+
+	return n.next
+}
+
+// setNext sets next.
+func (n *Node[T]) setNext(v *Node[T]) {
+	n.next = v
+}
@@ -0,0 +1,85 @@
+package parse
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMessage_getNil(t *testing.T) {
+	t.Parallel()
+
+	var m *Message
+
+	if m.GetID() != 0 || m.GetPayload() != nil || m.GetHeader() != nil || m.GetMeta() != (S1{}) ||
+		m.GetParent() != nil || m.GetReader() != nil || m.getVersion() != 0 {
+		t.Fatal("got non-zero values of the nil message")
+	}
+
+	// The getters chain through the nil pointers.
+	if id := m.GetParent().GetParent().GetID(); id != 0 {
+		t.Fatalf("got ID %d of the nil parent", id)
+	}
+}
+
+func TestMessage_get(t *testing.T) {
+	t.Parallel()
+
+	r := strings.NewReader("")
+	parent := &Message{ID: 1}
+	m := &Message{
+		ID:     2,
+		Header: map[string]string{"k": "v"},
+		Meta:   S1{S1Field1: "a"},
+		Parent: parent,
+		Reader: r,
+	}
+
+	m.SetPayload([]byte("payload"))
+	m.setVersion(3)
+
+	if m.GetID() != 2 || string(m.GetPayload()) != "payload" || !reflect.DeepEqual(m.GetHeader(), m.Header) ||
+		m.GetMeta() != m.Meta || m.GetParent() != parent || m.GetReader() != r || m.GetParent().GetID() != 1 ||
+		m.getVersion() != 3 {
+		t.Fatalf("got the getters values different from the fields of %+v", m)
+	}
+}
+
+func TestNode_getNil(t *testing.T) {
+	t.Parallel()
+
+	var n *Node[string]
+
+	if v := n.GetValue(); v != "" {
+		t.Fatalf("got value %q of the nil node", v)
+	}
+
+	n = &Node[string]{}
+	n.SetValue("v")
+
+	if v := n.GetValue(); v != "v" {
+		t.Fatalf("got value %q, want %q", v, "v")
+	}
+}
+
+func TestNode_unexported(t *testing.T) {
+	t.Parallel()
+
+	var n *Node[int]
+
+	// The unexported fields have the unexported nil-safe getters.
+	if next := n.getNext(); next != nil {
+		t.Fatalf("got next %v of the nil node", next)
+	}
+
+	n = &Node[int]{Value: 1}
+	n.setNext(&Node[int]{Value: 2})
+
+	if v := n.getNext().GetValue(); v != 2 {
+		t.Fatalf("got next value %d, want 2", v)
+	}
+
+	if next := n.getNext().getNext(); next != nil {
+		t.Fatalf("got next %v of the last node", next)
+	}
+}
@@ -0,0 +1,421 @@
+// Code generated by "genpls:breaker"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCircuitOpen is returned by the breaker wrappers while the circuit is open.
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+	// ErrRateLimited is returned by the breaker wrappers if the rate limit is exceeded.
+	ErrRateLimited = errors.New("rate limit is exceeded")
+)
+
+// BreakerPolicy configures the breaker wrappers.
+// Zero fields are set to the defaults of the wrapper.
+type BreakerPolicy struct {
+	// Failures is the number of consecutive failures opening the circuit.
+	Failures int
+	// Cooldown is the time the circuit stays open before letting a probe call through.
+	Cooldown time.Duration
+	// Successes is the number of successful probe calls closing the half-open circuit.
+	Successes int
+	// Rate is the number of calls allowed per second.
+	// If Rate is zero, the wrapper's default rate is used.
+	// Calls are not limited if Rate is negative or if both Rate and the default rate are zero.
+	Rate float64
+	// Burst is the maximum number of calls allowed at once by the limiter.
+	Burst int
+	// IsFailure reports whether the call failed with err counts as a failure.
+	// All errors are failures if IsFailure is nil.
+	IsFailure func(err error) bool
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// breaker is a circuit breaker with an optional token bucket limiter.
+type breaker struct {
+	mu        sync.Mutex
+	policy    BreakerPolicy
+	state     breakerState
+	failures  int
+	successes int
+	probing   bool
+	// generation is changed by every state transition, completions of calls allowed by other generations are stale.
+	generation uint64
+	openedAt   time.Time
+	tokens     float64
+	refilledAt time.Time
+}
+
+func newBreaker(policy BreakerPolicy) *breaker {
+	return &breaker{
+		policy:     policy,
+		tokens:     float64(policy.Burst),
+		refilledAt: time.Now(),
+	}
+}
+
+// allow returns the generation the call is allowed by or an error if the call must not proceed.
+// Allowed calls must be reported with done.
+func (b *breaker) allow() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+
+	if b.state == breakerOpen && now.Sub(b.openedAt) >= b.policy.Cooldown {
+		b.transit(breakerHalfOpen)
+		b.successes = 0
+	}
+
+	if b.state == breakerOpen || b.state == breakerHalfOpen && b.probing {
+		return 0, ErrCircuitOpen
+	}
+
+	if b.policy.Rate > 0 {
+		b.tokens = min(b.tokens+now.Sub(b.refilledAt).Seconds()*b.policy.Rate, float64(b.policy.Burst))
+		b.refilledAt = now
+
+		if b.tokens < 1 {
+			return 0, ErrRateLimited
+		}
+
+		b.tokens--
+	}
+
+	if b.state == breakerHalfOpen {
+		b.probing = true
+	}
+
+	return b.generation, nil
+}
+
+// done records the result of the call allowed by the generation, the panicked call is failed.
+// Results of the calls allowed before the last state transition are ignored.
+func (b *breaker) done(generation uint64, err error, panicked bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+
+	failed := panicked || err != nil && (b.policy.IsFailure == nil || b.policy.IsFailure(err))
+
+	switch b.state {
+	case breakerClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+
+		b.failures++
+		if b.failures >= b.policy.Failures {
+			b.open()
+		}
+	case breakerHalfOpen:
+		b.probing = false
+
+		if failed {
+			b.open()
+			return
+		}
+
+		b.successes++
+		if b.successes >= b.policy.Successes {
+			b.transit(breakerClosed)
+		}
+	case breakerOpen:
+	}
+}
+
+func (b *breaker) open() {
+	b.transit(breakerOpen)
+	b.openedAt = time.Now()
+	b.failures = 0
+}
+
+func (b *breaker) transit(state breakerState) {
+	b.state = state
+	b.probing = false
+	b.generation++
+}
+
+// *BreakerI3 implements I3.
+type BreakerI3 struct {
+	v       I3
+	breaker *breaker
+}
+
+// NewBreakerI3 returns a new *BreakerI3 guarding calls of v according to policy.
+func NewBreakerI3(v I3, policy BreakerPolicy) (*BreakerI3, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if policy.Failures <= 0 {
+		policy.Failures = 5
+	}
+
+	if policy.Cooldown <= 0 {
+		policy.Cooldown = 30 * time.Second
+	}
+
+	if policy.Successes <= 0 {
+		policy.Successes = 1
+	}
+
+	if policy.Burst <= 0 {
+		policy.Burst = 1
+	}
+
+	return &BreakerI3{
+		v:       v,
+		breaker: newBreaker(policy),
+	}, nil
+}
+
+func (w *BreakerI3) Method1(a int, b string) (r0 S1, r1 error) {
+	var generation uint64
+
+	if generation, r1 = w.breaker.allow(); r1 != nil {
+		return r0, r1
+	}
+
+	panicked := true
+
+	defer func() {
+		w.breaker.done(generation, r1, panicked)
+	}()
+
+	r0, r1 = w.v.Method1(a, b)
+	panicked = false
+
+	return r0, r1
+}
+
+func (w *BreakerI3) Method2(s *S4[string]) {
+	w.v.Method2(s)
+}
+
+// *BreakerRepo implements Repo.
+type BreakerRepo struct {
+	v       Repo
+	breaker *breaker
+}
+
+// NewBreakerRepo returns a new *BreakerRepo guarding calls of v according to policy.
+func NewBreakerRepo(v Repo, policy BreakerPolicy) (*BreakerRepo, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if policy.Failures <= 0 {
+		policy.Failures = 5
+	}
+
+	if policy.Cooldown <= 0 {
+		policy.Cooldown = 30 * time.Second
+	}
+
+	if policy.Successes <= 0 {
+		policy.Successes = 1
+	}
+
+	if policy.Rate == 0 {
+		policy.Rate = 10
+	}
+
+	if policy.Burst <= 0 {
+		policy.Burst = 5
+	}
+
+	return &BreakerRepo{
+		v:       v,
+		breaker: newBreaker(policy),
+	}, nil
+}
+
+func (w *BreakerRepo) GetItem(ctx context.Context, id string) (r0 Item, r1 error) {
+	var generation uint64
+
+	if generation, r1 = w.breaker.allow(); r1 != nil {
+		return r0, r1
+	}
+
+	panicked := true
+
+	defer func() {
+		w.breaker.done(generation, r1, panicked)
+	}()
+
+	r0, r1 = w.v.GetItem(ctx, id)
+	panicked = false
+
+	return r0, r1
+}
+
+func (w *BreakerRepo) PutItem(ctx context.Context, item Item) (r0 error) {
+	var generation uint64
+
+	if generation, r0 = w.breaker.allow(); r0 != nil {
+		return r0
+	}
+
+	panicked := true
+
+	defer func() {
+		w.breaker.done(generation, r0, panicked)
+	}()
+
+	r0 = w.v.PutItem(ctx, item)
+	panicked = false
+
+	return r0
+}
+
+func (w *BreakerRepo) ListItems(ctx context.Context) (r0 []Item, r1 error) {
+	var generation uint64
+
+	if generation, r1 = w.breaker.allow(); r1 != nil {
+		return r0, r1
+	}
+
+	panicked := true
+
+	defer func() {
+		w.breaker.done(generation, r1, panicked)
+	}()
+
+	r0, r1 = w.v.ListItems(ctx)
+	panicked = false
+
+	return r0, r1
+}
+
+func (w *BreakerRepo) DeleteItem(ctx context.Context, id string) (r0 error) {
+	var generation uint64
+
+	if generation, r0 = w.breaker.allow(); r0 != nil {
+		return r0
+	}
+
+	panicked := true
+
+	defer func() {
+		w.breaker.done(generation, r0, panicked)
+	}()
+
+	r0 = w.v.DeleteItem(ctx, id)
+	panicked = false
+
+	return r0
+}
+
+func (w *BreakerRepo) Count(ctx context.Context) (r0 int, r1 error) {
+	var generation uint64
+
+	if generation, r1 = w.breaker.allow(); r1 != nil {
+		return r0, r1
+	}
+
+	panicked := true
+
+	defer func() {
+		w.breaker.done(generation, r1, panicked)
+	}()
+
+	r0, r1 = w.v.Count(ctx)
+	panicked = false
+
+	return r0, r1
+}
+
+func (w *BreakerRepo) Close() (r0 error) {
+	return w.v.Close()
+}
+
+// *BreakerClash implements Clash.
+type BreakerClash struct {
+	v       Clash
+	breaker *breaker
+}
+
+// NewBreakerClash returns a new *BreakerClash guarding calls of v according to policy.
+func NewBreakerClash(v Clash, policy BreakerPolicy) (*BreakerClash, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if policy.Failures <= 0 {
+		policy.Failures = 5
+	}
+
+	if policy.Cooldown <= 0 {
+		policy.Cooldown = 30 * time.Second
+	}
+
+	if policy.Successes <= 0 {
+		policy.Successes = 1
+	}
+
+	if policy.Burst <= 0 {
+		policy.Burst = 1
+	}
+
+	return &BreakerClash{
+		v:       v,
+		breaker: newBreaker(policy),
+	}, nil
+}
+
+func (w *BreakerClash) A(ctx context.Context, r0 int) (r0_1 int, r1 error) {
+	var generation uint64
+
+	if generation, r1 = w.breaker.allow(); r1 != nil {
+		return r0_1, r1
+	}
+
+	panicked := true
+
+	defer func() {
+		w.breaker.done(generation, r1, panicked)
+	}()
+
+	r0_1, r1 = w.v.A(ctx, r0)
+	panicked = false
+
+	return r0_1, r1
+}
+
+func (w *BreakerClash) B(res string, returns int, ok bool, call int) (r0 string, r1 error) {
+	var generation uint64
+
+	if generation, r1 = w.breaker.allow(); r1 != nil {
+		return r0, r1
+	}
+
+	panicked := true
+
+	defer func() {
+		w.breaker.done(generation, r1, panicked)
+	}()
+
+	r0, r1 = w.v.B(res, returns, ok, call)
+	panicked = false
+
+	return r0, r1
+}
@@ -0,0 +1,63 @@
+// Code generated by "genpls:breaker"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// *BreakerShared implements Shared.
+type BreakerShared struct {
+	v       Shared
+	breaker *breaker
+}
+
+// NewBreakerShared returns a new *BreakerShared guarding calls of v according to policy.
+func NewBreakerShared(v Shared, policy BreakerPolicy) (*BreakerShared, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if policy.Failures <= 0 {
+		policy.Failures = 5
+	}
+
+	if policy.Cooldown <= 0 {
+		policy.Cooldown = 30 * time.Second
+	}
+
+	if policy.Successes <= 0 {
+		policy.Successes = 1
+	}
+
+	if policy.Burst <= 0 {
+		policy.Burst = 1
+	}
+
+	return &BreakerShared{
+		v:       v,
+		breaker: newBreaker(policy),
+	}, nil
+}
+
+func (w *BreakerShared) Get(ctx context.Context, id string) (r0 string, r1 error) {
+	var generation uint64
+
+	if generation, r1 = w.breaker.allow(); r1 != nil {
+		return r0, r1
+	}
+
+	panicked := true
+
+	defer func() {
+		w.breaker.done(generation, r1, panicked)
+	}()
+
+	r0, r1 = w.v.Get(ctx, id)
+	panicked = false
+
+	return r0, r1
+}
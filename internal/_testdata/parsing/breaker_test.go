@@ -0,0 +1,277 @@
+package parse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errBreakerCall = errors.New("call failed")
+
+// failingShared returns the error of the call while fail is true.
+func failingShared(fail *bool) sharedFunc {
+	return func(_ context.Context, id string) (string, error) {
+		if *fail {
+			return "", errBreakerCall
+		}
+
+		return id, nil
+	}
+}
+
+func newTestBreaker(t *testing.T, v Shared, policy BreakerPolicy) *BreakerShared {
+	t.Helper()
+
+	w, err := NewBreakerShared(v, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return w
+}
+
+func TestBreakerShared_opensAfterFailures(t *testing.T) {
+	t.Parallel()
+
+	fail := true
+	w := newTestBreaker(t, failingShared(&fail), BreakerPolicy{Failures: 3, Cooldown: time.Hour})
+
+	for i := range 3 {
+		if _, err := w.Get(context.Background(), "id"); !errors.Is(err, errBreakerCall) {
+			t.Fatalf("call %d: got error %v, want %v", i, err, errBreakerCall)
+		}
+	}
+
+	fail = false
+
+	if _, err := w.Get(context.Background(), "id"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v, want %v", err, ErrCircuitOpen)
+	}
+}
+
+func TestBreakerShared_successResetsFailures(t *testing.T) {
+	t.Parallel()
+
+	fail := true
+	w := newTestBreaker(t, failingShared(&fail), BreakerPolicy{Failures: 2, Cooldown: time.Hour})
+
+	for i, f := range []bool{true, false, true, false} {
+		fail = f
+
+		if _, err := w.Get(context.Background(), "id"); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: the circuit must stay closed", i)
+		}
+	}
+}
+
+func TestBreakerShared_halfOpen(t *testing.T) {
+	t.Parallel()
+
+	const cooldown = 10 * time.Millisecond
+
+	tests := []struct {
+		name string
+		// fail is the result of the probe call.
+		fail bool
+		want error
+	}{
+		{name: "success closes", fail: false, want: nil},
+		{name: "failure opens", fail: true, want: ErrCircuitOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fail := true
+			w := newTestBreaker(t, failingShared(&fail), BreakerPolicy{Failures: 1, Cooldown: cooldown})
+
+			_, _ = w.Get(context.Background(), "id")
+
+			if _, err := w.Get(context.Background(), "id"); !errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("got error %v, want %v before the cooldown", err, ErrCircuitOpen)
+			}
+
+			time.Sleep(cooldown)
+
+			fail = tt.fail
+
+			// The probe call is let through after the cooldown.
+			if _, err := w.Get(context.Background(), "id"); errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("got error %v, want the probe call after the cooldown", err)
+			}
+
+			fail = false
+
+			if _, err := w.Get(context.Background(), "id"); !errors.Is(err, tt.want) {
+				t.Fatalf("got error %v, want %v after the probe", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakerShared_halfOpenSingleProbe(t *testing.T) {
+	t.Parallel()
+
+	const cooldown = 10 * time.Millisecond
+
+	fail := true
+	probing := make(chan struct{})
+	release := make(chan struct{})
+
+	w := newTestBreaker(t, sharedFunc(func(_ context.Context, id string) (string, error) {
+		if fail {
+			return "", errBreakerCall
+		}
+
+		close(probing)
+		<-release
+
+		return id, nil
+	}), BreakerPolicy{Failures: 1, Cooldown: cooldown})
+
+	_, _ = w.Get(context.Background(), "id")
+
+	time.Sleep(cooldown)
+
+	fail = false
+	done := make(chan error)
+
+	go func() {
+		_, err := w.Get(context.Background(), "id")
+		done <- err
+	}()
+
+	<-probing
+
+	if _, err := w.Get(context.Background(), "id"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v, want %v while probing", err, ErrCircuitOpen)
+	}
+
+	close(release)
+
+	if err := <-done; err != nil {
+		t.Fatalf("probe: got error %v", err)
+	}
+}
+
+func TestBreakerShared_rate(t *testing.T) {
+	t.Parallel()
+
+	w := newTestBreaker(t, failingShared(new(bool)), BreakerPolicy{Rate: 1, Burst: 2})
+
+	for i := range 2 {
+		if _, err := w.Get(context.Background(), "id"); err != nil {
+			t.Fatalf("call %d: got error %v", i, err)
+		}
+	}
+
+	if _, err := w.Get(context.Background(), "id"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("got error %v, want %v", err, ErrRateLimited)
+	}
+}
+
+func TestBreakerRepo_defaultRate(t *testing.T) {
+	t.Parallel()
+
+	// The directive limits Repo by -rate=10 -burst=5 by default.
+	w, err := NewBreakerRepo(&UnimplementedRepo{}, BreakerPolicy{IsFailure: func(error) bool { return false }})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range 5 {
+		if _, err := w.Count(context.Background()); errors.Is(err, ErrRateLimited) {
+			t.Fatalf("call %d: got error %v", i, err)
+		}
+	}
+
+	if _, err := w.Count(context.Background()); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("got error %v, want %v", err, ErrRateLimited)
+	}
+
+	// Negative rate disables the limiter.
+	w, err = NewBreakerRepo(&UnimplementedRepo{}, BreakerPolicy{Rate: -1, IsFailure: func(error) bool { return false }})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range 10 {
+		if _, err := w.Count(context.Background()); errors.Is(err, ErrRateLimited) {
+			t.Fatalf("call %d: got error %v", i, err)
+		}
+	}
+}
+
+func TestBreakerShared_staleCompletion(t *testing.T) {
+	t.Parallel()
+
+	const cooldown = 10 * time.Millisecond
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	w := newTestBreaker(t, sharedFunc(func(_ context.Context, id string) (string, error) {
+		switch id {
+		case "slow":
+			close(started)
+			<-release
+
+			return "", errBreakerCall
+		case "fail":
+			return "", errBreakerCall
+		}
+
+		return id, nil
+	}), BreakerPolicy{Failures: 1, Cooldown: cooldown})
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		_, _ = w.Get(context.Background(), "slow")
+	}()
+
+	<-started
+
+	// The circuit opens and closes again while the slow call is in flight.
+	_, _ = w.Get(context.Background(), "fail")
+
+	time.Sleep(cooldown)
+
+	if _, err := w.Get(context.Background(), "probe"); err != nil {
+		t.Fatalf("probe: got error %v", err)
+	}
+
+	close(release)
+	<-done
+
+	// The failure of the slow call allowed by the closed circuit before it was opened is ignored.
+	if _, err := w.Get(context.Background(), "ok"); err != nil {
+		t.Fatalf("got error %v, want the stale failure ignored", err)
+	}
+}
+
+func TestBreakerShared_panicFails(t *testing.T) {
+	t.Parallel()
+
+	w := newTestBreaker(t, sharedFunc(func(context.Context, string) (string, error) {
+		panic("boom")
+	}), BreakerPolicy{Failures: 1, Cooldown: time.Hour})
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("the panic must be propagated")
+			}
+		}()
+
+		_, _ = w.Get(context.Background(), "id")
+	}()
+
+	if _, err := w.Get(context.Background(), "id"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v, want %v after the panic", err, ErrCircuitOpen)
+	}
+}
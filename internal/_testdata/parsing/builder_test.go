@@ -0,0 +1,74 @@
+package parse
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	got, err := NewConfigBuilder().
+		WithID("id").
+		WithName("name").
+		WithTags([]string{"a"}).
+		WithRetries(2).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Config{ID: "id", Name: "name", Tags: []string{"a"}, retries: 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestConfigBuilder_required(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		builder *ConfigBuilder
+		missing string
+	}{
+		{"ID by the flag", NewConfigBuilder().WithName("name"), "ID"},
+		{"Name by the tag", NewConfigBuilder().WithID("id"), "Name"},
+		// The required field set to its zero value is set.
+		{"zero values", NewConfigBuilder().WithID("").WithName(""), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.builder.Build()
+
+			if tt.missing == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), "required field "+tt.missing+" ") {
+				t.Fatalf("got error %v, want the error naming %s", err, tt.missing)
+			}
+
+			if !reflect.DeepEqual(got, Config{}) {
+				t.Fatalf("got %+v, want zero Config on error", got)
+			}
+		})
+	}
+}
+
+func TestS4Builder_Build(t *testing.T) {
+	t.Parallel()
+
+	got, err := NewS4Builder[int]().WithS4Field(1).Build()
+	if err != nil || got.S4Field != 1 {
+		t.Fatalf("got %+v, %v, want S4Field set", got, err)
+	}
+}
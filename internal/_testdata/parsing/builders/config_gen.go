@@ -0,0 +1,62 @@
+// Code generated by "genpls:builder"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package builders
+
+import (
+	"errors"
+	io_1 "io"
+	"parse"
+)
+
+// *ConfigBuilder builds Config by the chainable setters.
+type ConfigBuilder struct {
+	v       parse.Config
+	hasName bool
+}
+
+// NewConfigBuilder returns a new *ConfigBuilder building the zero Config.
+func NewConfigBuilder() *ConfigBuilder {
+	return &ConfigBuilder{}
+}
+
+// WithID sets ID.
+//
+// ID identifies the config.
+func (b *ConfigBuilder) WithID(v string) *ConfigBuilder {
+	b.v.ID = v
+
+	return b
+}
+
+// WithName sets Name.
+func (b *ConfigBuilder) WithName(v string) *ConfigBuilder {
+	b.v.Name = v
+	b.hasName = true
+
+	return b
+}
+
+// WithTags sets Tags.
+func (b *ConfigBuilder) WithTags(v []string) *ConfigBuilder {
+	b.v.Tags = v
+
+	return b
+}
+
+// WithReader sets Reader.
+func (b *ConfigBuilder) WithReader(v io_1.Reader) *ConfigBuilder {
+	b.v.Reader = v
+
+	return b
+}
+
+// Build returns the built Config.
+// It returns an error if any of the required fields is not set.
+func (b *ConfigBuilder) Build() (parse.Config, error) {
+	if !b.hasName {
+		return parse.Config{}, errors.New("ConfigBuilder: required field Name is not set")
+	}
+
+	return b.v, nil
+}
@@ -0,0 +1,20 @@
+package builders
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigBuilder_required(t *testing.T) {
+	t.Parallel()
+
+	// Only Name is required by the tag, -required=ID is given to the other builder.
+	if _, err := NewConfigBuilder().WithID("id").Build(); err == nil || !strings.Contains(err.Error(), "required field Name ") {
+		t.Fatalf("got error %v, want the error naming Name", err)
+	}
+
+	got, err := NewConfigBuilder().WithName("name").Build()
+	if err != nil || got.Name != "name" || got.ID != "" {
+		t.Fatalf("got %+v, %v, want Config built without ID", got, err)
+	}
+}
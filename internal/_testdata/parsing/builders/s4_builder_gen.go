@@ -0,0 +1,32 @@
+// Code generated by "genpls:builder"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package builders
+
+import (
+	"parse"
+)
+
+// *S4Builder builds S4 by the chainable setters.
+type S4Builder[T any] struct {
+	v parse.S4[T]
+}
+
+// NewS4Builder returns a new *S4Builder building the zero S4.
+func NewS4Builder[T any]() *S4Builder[T] {
+	return &S4Builder[T]{}
+}
+
+// WithS4Field sets S4Field.
+//
+// S4Field doc
+func (b *S4Builder[T]) WithS4Field(v T) *S4Builder[T] {
+	b.v.S4Field = v
+
+	return b
+}
+
+// Build returns the built S4.
+func (b *S4Builder[T]) Build() (parse.S4[T], error) {
+	return b.v, nil
+}
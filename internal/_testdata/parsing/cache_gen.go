@@ -0,0 +1,347 @@
+// Code generated by "genpls:cache"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// cacheLRU is a least recently used cache which entries expire after ttl.
+type cacheLRU[K comparable, V any] struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[K]*list.Element
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+func newCacheLRU[K comparable, V any](size int, ttl time.Duration) *cacheLRU[K, V] {
+	return &cacheLRU[K, V]{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[K]*list.Element, size),
+	}
+}
+
+func (c *cacheLRU[K, V]) get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return value, false
+	}
+
+	entry := elem.Value.(*cacheEntry[K, V])
+
+	if time.Now().After(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+
+		return value, false
+	}
+
+	c.order.MoveToFront(elem)
+
+	return entry.value, true
+}
+
+func (c *cacheLRU[K, V]) put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := time.Now().Add(c.ttl)
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry[K, V])
+		entry.value = value
+		entry.expires = expires
+
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry[K, V]{key: key, value: value, expires: expires})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry[K, V]).key)
+	}
+}
+
+func (c *cacheLRU[K, V]) remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// cacheI3Method1Key is the key of cached results of I3.Method1.
+type cacheI3Method1Key struct {
+	a int
+	b string
+}
+
+// *CacheI3 implements I3.
+type CacheI3 struct {
+	v I3
+
+	cacheMethod1 *cacheLRU[cacheI3Method1Key, struct{ r0 S1 }]
+}
+
+// NewCacheI3 returns a new *CacheI3 caching results of v.
+func NewCacheI3(v I3) (*CacheI3, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	return &CacheI3{
+		v:            v,
+		cacheMethod1: newCacheLRU[cacheI3Method1Key, struct{ r0 S1 }](1024, 1*time.Minute),
+	}, nil
+}
+
+func (w *CacheI3) Method1(a int, b string) (r0 S1, r1 error) {
+	cacheKey := cacheI3Method1Key{a: a, b: b}
+	if cached, ok := w.cacheMethod1.get(cacheKey); ok {
+		return cached.r0, nil
+	}
+
+	r0, r1 = w.v.Method1(a, b)
+	if r1 == nil {
+		w.cacheMethod1.put(cacheKey, struct{ r0 S1 }{r0})
+	}
+
+	return r0, r1
+}
+
+// InvalidateMethod1 removes the cached results of Method1 called with the arguments.
+func (w *CacheI3) InvalidateMethod1(a int, b string) {
+	w.cacheMethod1.remove(cacheI3Method1Key{a: a, b: b})
+}
+
+func (w *CacheI3) Method2(s *S4[string]) {
+	w.v.Method2(s)
+}
+
+// cacheRepoGetItemKey is the key of cached results of Repo.GetItem.
+type cacheRepoGetItemKey struct {
+	id string
+}
+
+// cacheRepoListItemsKey is the key of cached results of Repo.ListItems.
+type cacheRepoListItemsKey struct {
+}
+
+// *CacheRepo implements Repo.
+type CacheRepo struct {
+	v Repo
+
+	cacheGetItem   *cacheLRU[cacheRepoGetItemKey, struct{ r0 Item }]
+	cacheListItems *cacheLRU[cacheRepoListItemsKey, struct{ r0 []Item }]
+}
+
+// NewCacheRepo returns a new *CacheRepo caching results of v.
+func NewCacheRepo(v Repo) (*CacheRepo, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	return &CacheRepo{
+		v:              v,
+		cacheGetItem:   newCacheLRU[cacheRepoGetItemKey, struct{ r0 Item }](128, 5*time.Minute),
+		cacheListItems: newCacheLRU[cacheRepoListItemsKey, struct{ r0 []Item }](128, 1*time.Minute),
+	}, nil
+}
+
+func (w *CacheRepo) GetItem(ctx context.Context, id string) (r0 Item, r1 error) {
+	cacheKey := cacheRepoGetItemKey{id: id}
+	if cached, ok := w.cacheGetItem.get(cacheKey); ok {
+		return cached.r0, nil
+	}
+
+	r0, r1 = w.v.GetItem(ctx, id)
+	if r1 == nil {
+		w.cacheGetItem.put(cacheKey, struct{ r0 Item }{r0})
+	}
+
+	return r0, r1
+}
+
+// InvalidateGetItem removes the cached results of GetItem called with the arguments.
+func (w *CacheRepo) InvalidateGetItem(id string) {
+	w.cacheGetItem.remove(cacheRepoGetItemKey{id: id})
+}
+
+func (w *CacheRepo) PutItem(ctx context.Context, item Item) (r0 error) {
+	return w.v.PutItem(ctx, item)
+}
+
+func (w *CacheRepo) ListItems(ctx context.Context) (r0 []Item, r1 error) {
+	cacheKey := cacheRepoListItemsKey{}
+	if cached, ok := w.cacheListItems.get(cacheKey); ok {
+		return cached.r0, nil
+	}
+
+	r0, r1 = w.v.ListItems(ctx)
+	if r1 == nil {
+		w.cacheListItems.put(cacheKey, struct{ r0 []Item }{r0})
+	}
+
+	return r0, r1
+}
+
+// InvalidateListItems removes the cached results of ListItems called with the arguments.
+func (w *CacheRepo) InvalidateListItems() {
+	w.cacheListItems.remove(cacheRepoListItemsKey{})
+}
+
+func (w *CacheRepo) DeleteItem(ctx context.Context, id string) (r0 error) {
+	return w.v.DeleteItem(ctx, id)
+}
+
+func (w *CacheRepo) Count(ctx context.Context) (r0 int, r1 error) {
+	return w.v.Count(ctx)
+}
+
+func (w *CacheRepo) Close() (r0 error) {
+	return w.v.Close()
+}
+
+// cacheKeyedGetKey is the key of cached results of Keyed.Get.
+type cacheKeyedGetKey[V any] struct {
+	key string
+}
+
+// *CacheKeyed implements Keyed.
+type CacheKeyed[V any] struct {
+	v Keyed[V]
+
+	cacheGet *cacheLRU[cacheKeyedGetKey[V], struct{ r0 []V }]
+}
+
+// NewCacheKeyed returns a new *CacheKeyed caching results of v.
+func NewCacheKeyed[V any](v Keyed[V]) (*CacheKeyed[V], error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	return &CacheKeyed[V]{
+		v:        v,
+		cacheGet: newCacheLRU[cacheKeyedGetKey[V], struct{ r0 []V }](1024, 30*time.Second),
+	}, nil
+}
+
+// Get returns the value stored by the key.
+func (w *CacheKeyed[V]) Get(key string) (r0 []V, r1 error) {
+	cacheKey := cacheKeyedGetKey[V]{key: key}
+	if cached, ok := w.cacheGet.get(cacheKey); ok {
+		return cached.r0, nil
+	}
+
+	r0, r1 = w.v.Get(key)
+	if r1 == nil {
+		w.cacheGet.put(cacheKey, struct{ r0 []V }{r0})
+	}
+
+	return r0, r1
+}
+
+// InvalidateGet removes the cached results of Get called with the arguments.
+func (w *CacheKeyed[V]) InvalidateGet(key string) {
+	w.cacheGet.remove(cacheKeyedGetKey[V]{key: key})
+}
+
+// Keys returns the stored keys.
+func (w *CacheKeyed[V]) Keys() (r0 []string) {
+	return w.v.Keys()
+}
+
+// cacheClashAKey is the key of cached results of Clash.A.
+type cacheClashAKey struct {
+	r0 int
+}
+
+// cacheClashBKey is the key of cached results of Clash.B.
+type cacheClashBKey struct {
+	res     string
+	returns int
+	ok      bool
+	call    int
+}
+
+// *CacheClash implements Clash.
+type CacheClash struct {
+	v Clash
+
+	cacheA *cacheLRU[cacheClashAKey, struct{ r0_1 int }]
+	cacheB *cacheLRU[cacheClashBKey, struct{ r0 string }]
+}
+
+// NewCacheClash returns a new *CacheClash caching results of v.
+func NewCacheClash(v Clash) (*CacheClash, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	return &CacheClash{
+		v:      v,
+		cacheA: newCacheLRU[cacheClashAKey, struct{ r0_1 int }](1024, 1*time.Minute),
+		cacheB: newCacheLRU[cacheClashBKey, struct{ r0 string }](1024, 1*time.Minute),
+	}, nil
+}
+
+func (w *CacheClash) A(ctx context.Context, r0 int) (r0_1 int, r1 error) {
+	cacheKey := cacheClashAKey{r0: r0}
+	if cached, ok := w.cacheA.get(cacheKey); ok {
+		return cached.r0_1, nil
+	}
+
+	r0_1, r1 = w.v.A(ctx, r0)
+	if r1 == nil {
+		w.cacheA.put(cacheKey, struct{ r0_1 int }{r0_1})
+	}
+
+	return r0_1, r1
+}
+
+// InvalidateA removes the cached results of A called with the arguments.
+func (w *CacheClash) InvalidateA(r0 int) {
+	w.cacheA.remove(cacheClashAKey{r0: r0})
+}
+
+func (w *CacheClash) B(res string, returns int, ok bool, call int) (r0 string, r1 error) {
+	cacheKey := cacheClashBKey{res: res, returns: returns, ok: ok, call: call}
+	if cached, ok := w.cacheB.get(cacheKey); ok {
+		return cached.r0, nil
+	}
+
+	r0, r1 = w.v.B(res, returns, ok, call)
+	if r1 == nil {
+		w.cacheB.put(cacheKey, struct{ r0 string }{r0})
+	}
+
+	return r0, r1
+}
+
+// InvalidateB removes the cached results of B called with the arguments.
+func (w *CacheClash) InvalidateB(res string, returns int, ok bool, call int) {
+	w.cacheB.remove(cacheClashBKey{res: res, returns: returns, ok: ok, call: call})
+}
@@ -0,0 +1,96 @@
+// Code generated by "genpls:cache"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// cacheSharedGetKey is the key of cached results of Shared.Get.
+type cacheSharedGetKey struct {
+	id string
+}
+
+// *CacheShared implements Shared.
+type CacheShared struct {
+	v Shared
+
+	cacheGet *cacheLRU[cacheSharedGetKey, struct{ r0 string }]
+}
+
+// NewCacheShared returns a new *CacheShared caching results of v.
+func NewCacheShared(v Shared) (*CacheShared, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	return &CacheShared{
+		v:        v,
+		cacheGet: newCacheLRU[cacheSharedGetKey, struct{ r0 string }](1024, 1*time.Minute),
+	}, nil
+}
+
+func (w *CacheShared) Get(ctx context.Context, id string) (r0 string, r1 error) {
+	cacheKey := cacheSharedGetKey{id: id}
+	if cached, ok := w.cacheGet.get(cacheKey); ok {
+		return cached.r0, nil
+	}
+
+	r0, r1 = w.v.Get(ctx, id)
+	if r1 == nil {
+		w.cacheGet.put(cacheKey, struct{ r0 string }{r0})
+	}
+
+	return r0, r1
+}
+
+// InvalidateGet removes the cached results of Get called with the arguments.
+func (w *CacheShared) InvalidateGet(id string) {
+	w.cacheGet.remove(cacheSharedGetKey{id: id})
+}
+
+// cacheShortCachedGetKey is the key of cached results of ShortCached.Get.
+type cacheShortCachedGetKey struct {
+	id string
+}
+
+// *CacheShortCached implements ShortCached.
+type CacheShortCached struct {
+	v ShortCached
+
+	cacheGet *cacheLRU[cacheShortCachedGetKey, struct{ r0 string }]
+}
+
+// NewCacheShortCached returns a new *CacheShortCached caching results of v.
+func NewCacheShortCached(v ShortCached) (*CacheShortCached, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	return &CacheShortCached{
+		v:        v,
+		cacheGet: newCacheLRU[cacheShortCachedGetKey, struct{ r0 string }](2, 50*time.Millisecond),
+	}, nil
+}
+
+func (w *CacheShortCached) Get(ctx context.Context, id string) (r0 string, r1 error) {
+	cacheKey := cacheShortCachedGetKey{id: id}
+	if cached, ok := w.cacheGet.get(cacheKey); ok {
+		return cached.r0, nil
+	}
+
+	r0, r1 = w.v.Get(ctx, id)
+	if r1 == nil {
+		w.cacheGet.put(cacheKey, struct{ r0 string }{r0})
+	}
+
+	return r0, r1
+}
+
+// InvalidateGet removes the cached results of Get called with the arguments.
+func (w *CacheShortCached) InvalidateGet(id string) {
+	w.cacheGet.remove(cacheShortCachedGetKey{id: id})
+}
@@ -0,0 +1,119 @@
+package parse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errCacheCall = errors.New("call failed")
+
+// callsShared counts the calls by the id and fails the calls of the id "fail".
+func callsShared(calls map[string]int) sharedFunc {
+	return func(_ context.Context, id string) (string, error) {
+		calls[id]++
+		if id == "fail" {
+			return "", errCacheCall
+		}
+
+		return id, nil
+	}
+}
+
+func newTestCache(t *testing.T, calls map[string]int) *CacheShortCached {
+	t.Helper()
+
+	w, err := NewCacheShortCached(callsShared(calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return w
+}
+
+// get calls Get checking the result.
+func get(t *testing.T, w *CacheShortCached, id string) {
+	t.Helper()
+
+	got, err := w.Get(context.Background(), id)
+	if err != nil || got != id {
+		t.Fatalf("got %q, %v, want %q", got, err, id)
+	}
+}
+
+func TestCacheShortCached_ttl(t *testing.T) {
+	t.Parallel()
+
+	calls := map[string]int{}
+	w := newTestCache(t, calls)
+
+	get(t, w, "a")
+	get(t, w, "a")
+
+	if calls["a"] != 1 {
+		t.Fatalf("got %d calls, want the result cached", calls["a"])
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	get(t, w, "a")
+
+	if calls["a"] != 2 {
+		t.Fatalf("got %d calls, want the result expired", calls["a"])
+	}
+}
+
+func TestCacheShortCached_evict(t *testing.T) {
+	t.Parallel()
+
+	calls := map[string]int{}
+	w := newTestCache(t, calls)
+
+	// The cache of size 2 evicts the least recently used b.
+	for _, id := range []string{"a", "b", "a", "c", "a", "b"} {
+		get(t, w, id)
+	}
+
+	want := map[string]int{"a": 1, "b": 2, "c": 1}
+
+	for id, n := range want {
+		if calls[id] != n {
+			t.Fatalf("got %d calls of %q, want %d", calls[id], id, n)
+		}
+	}
+}
+
+func TestCacheShortCached_invalidate(t *testing.T) {
+	t.Parallel()
+
+	calls := map[string]int{}
+	w := newTestCache(t, calls)
+
+	get(t, w, "a")
+	get(t, w, "b")
+	w.InvalidateGet("a")
+	get(t, w, "a")
+	get(t, w, "b")
+
+	if calls["a"] != 2 || calls["b"] != 1 {
+		t.Fatalf("got calls %v, want a called again after the invalidation only", calls)
+	}
+}
+
+func TestCacheShortCached_errorNotCached(t *testing.T) {
+	t.Parallel()
+
+	calls := map[string]int{}
+	w := newTestCache(t, calls)
+
+	for range 2 {
+		if _, err := w.Get(context.Background(), "fail"); !errors.Is(err, errCacheCall) {
+			t.Fatalf("got error %v, want %v", err, errCacheCall)
+		}
+	}
+
+	if calls["fail"] != 2 {
+		t.Fatalf("got %d calls, want the error not cached", calls["fail"])
+	}
+}
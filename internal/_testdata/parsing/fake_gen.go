@@ -0,0 +1,213 @@
+// Code generated by "genpls:fake"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrFakeRepoNotFound is returned by *FakeRepo if the item is not stored.
+var ErrFakeRepoNotFound = errors.New("FakeRepo: not found")
+
+// *FakeRepo implements Repo.
+type FakeRepo struct {
+	mu    sync.RWMutex
+	items map[string]Item
+	keys  []string
+	key   func(Item) string
+}
+
+// NewFakeRepo returns a new empty *FakeRepo.
+// The key function returns the key of the stored item.
+func NewFakeRepo(key func(Item) string) (*FakeRepo, error) {
+	if key == nil {
+		return nil, errors.New("key is nil")
+	}
+	return &FakeRepo{
+		items: map[string]Item{},
+		key:   key,
+	}, nil
+}
+
+func (f *FakeRepo) GetItem(ctx context.Context, id string) (Item, error) {
+	if err := ctx.Err(); err != nil {
+		var zero Item
+		return zero, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	v, ok := f.items[id]
+	if !ok {
+		return v, ErrFakeRepoNotFound
+	}
+
+	return v, nil
+}
+
+func (f *FakeRepo) PutItem(ctx context.Context, item Item) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.items == nil {
+		f.items = map[string]Item{}
+	}
+
+	k := f.key(item)
+
+	if _, ok := f.items[k]; !ok {
+		f.keys = append(f.keys, k)
+	}
+
+	f.items[k] = item
+
+	return nil
+}
+
+func (f *FakeRepo) ListItems(ctx context.Context) ([]Item, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	items := make([]Item, 0, len(f.keys))
+
+	for _, k := range f.keys {
+		items = append(items, f.items[k])
+	}
+
+	return items, nil
+}
+
+func (f *FakeRepo) DeleteItem(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.items[id]; !ok {
+		return ErrFakeRepoNotFound
+	}
+
+	delete(f.items, id)
+
+	for i, k := range f.keys {
+		if k == id {
+			f.keys = append(f.keys[:i], f.keys[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+func (*FakeRepo) Count(ctx context.Context) (int, error) {
+	panic("method Count is not implemented!")
+}
+
+func (*FakeRepo) Close() error {
+	panic("method Close is not implemented!")
+}
+
+// ErrFakeStoreNotFound is returned by *FakeStore if the item is not stored.
+var ErrFakeStoreNotFound = errors.New("FakeStore: not found")
+
+// *FakeStore implements Store.
+type FakeStore[K comparable, V any] struct {
+	mu    sync.RWMutex
+	items map[K]V
+	keys  []K
+	key   func(V) K
+}
+
+// NewFakeStore returns a new empty *FakeStore.
+// The key function returns the key of the stored item.
+func NewFakeStore[K comparable, V any](key func(V) K) (*FakeStore[K, V], error) {
+	if key == nil {
+		return nil, errors.New("key is nil")
+	}
+	return &FakeStore[K, V]{
+		items: map[K]V{},
+		key:   key,
+	}, nil
+}
+
+func (f *FakeStore[K, V]) Get(ctx context.Context, key K) (V, error) {
+	if err := ctx.Err(); err != nil {
+		var zero V
+		return zero, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	v, ok := f.items[key]
+	if !ok {
+		return v, ErrFakeStoreNotFound
+	}
+
+	return v, nil
+}
+
+func (*FakeStore[K, V]) GetByName(ctx context.Context, name string) (V, error) {
+	panic("method GetByName is not implemented!")
+}
+
+func (f *FakeStore[K, V]) Put(ctx context.Context, v_1 V) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.items == nil {
+		f.items = map[K]V{}
+	}
+
+	k := f.key(v_1)
+
+	if _, ok := f.items[k]; !ok {
+		f.keys = append(f.keys, k)
+	}
+
+	f.items[k] = v_1
+
+	return nil
+}
+
+func (f *FakeStore[K, V]) Delete(ctx context.Context, key K) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.items[key]; !ok {
+		return ErrFakeStoreNotFound
+	}
+
+	delete(f.items, key)
+
+	for i, k := range f.keys {
+		if k == key {
+			f.keys = append(f.keys[:i], f.keys[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
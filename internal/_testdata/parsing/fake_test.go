@@ -0,0 +1,127 @@
+package parse
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newTestFakeRepo(t *testing.T) *FakeRepo {
+	t.Helper()
+
+	f, err := NewFakeRepo(func(item Item) string { return item.ID })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return f
+}
+
+func TestFakeRepo_crud(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	f := newTestFakeRepo(t)
+
+	if err := f.PutItem(ctx, Item{ID: "1", Name: "one"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := f.GetItem(ctx, "1"); err != nil || got != (Item{ID: "1", Name: "one"}) {
+		t.Fatalf("got %+v, %v, want the put item", got, err)
+	}
+
+	// Put replaces the item with the same key.
+	if err := f.PutItem(ctx, Item{ID: "1", Name: "uno"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := f.GetItem(ctx, "1"); err != nil || got.Name != "uno" {
+		t.Fatalf("got %+v, %v, want the updated item", got, err)
+	}
+
+	if err := f.DeleteItem(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.GetItem(ctx, "1"); !errors.Is(err, ErrFakeRepoNotFound) {
+		t.Fatalf("got error %v, want %v after the delete", err, ErrFakeRepoNotFound)
+	}
+}
+
+func TestFakeRepo_notFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	f := newTestFakeRepo(t)
+
+	if _, err := f.GetItem(ctx, "1"); !errors.Is(err, ErrFakeRepoNotFound) {
+		t.Fatalf("get: got error %v, want %v", err, ErrFakeRepoNotFound)
+	}
+
+	if err := f.DeleteItem(ctx, "1"); !errors.Is(err, ErrFakeRepoNotFound) {
+		t.Fatalf("delete: got error %v, want %v", err, ErrFakeRepoNotFound)
+	}
+}
+
+func TestFakeRepo_listOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	f := newTestFakeRepo(t)
+
+	for _, id := range []string{"c", "a", "b", "a"} {
+		if err := f.PutItem(ctx, Item{ID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := f.DeleteItem(ctx, "c"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.PutItem(ctx, Item{ID: "c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Items are listed in the order they are first put, updates keep the position.
+	got, err := f.ListItems(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []Item{{ID: "a"}, {ID: "b"}, {ID: "c"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestFakeRepo_canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := newTestFakeRepo(t).PutItem(ctx, Item{ID: "1"}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestFakeStore_generic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	f, err := NewFakeStore[int](func(s S1) int { return s.S1Field2 })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Put(ctx, S1{S1Field1: "one", S1Field2: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := f.Get(ctx, 1); err != nil || got.S1Field1 != "one" {
+		t.Fatalf("got %+v, %v, want the put item", got, err)
+	}
+}
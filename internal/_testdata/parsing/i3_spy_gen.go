@@ -0,0 +1,211 @@
+// Code generated by "genpls:mock"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// *MockI3 implements I3.
+type MockI3 struct {
+	Method1Func func(a int, b string) (S1, error)
+	Method2Func func(s *S4[string])
+
+	Calls struct {
+		Method1 []struct {
+			a     int
+			b     string
+			r0    S1
+			r1    error
+			Seq   uint64
+			Start time.Time
+			End   time.Time
+		}
+		Method2 []struct {
+			s     *S4[string]
+			Seq   uint64
+			Start time.Time
+			End   time.Time
+		}
+	}
+
+	impl I3
+
+	seq uint64
+
+	returns struct {
+		Method1 struct {
+			queue []struct {
+				r0 S1
+				r1 error
+			}
+			onCall map[int]struct {
+				r0 S1
+				r1 error
+			}
+			next int
+		}
+	}
+}
+
+// NewMockI3 returns a new *MockI3 which calls impl for the methods with nil funcs.
+func NewMockI3(impl I3) *MockI3 {
+	return &MockI3{impl: impl}
+}
+
+// MockI3Call is a record of the MockI3's method call.
+type MockI3Call struct {
+	Method  string
+	Seq     uint64
+	Start   time.Time
+	End     time.Time
+	Args    []any
+	Results []any
+}
+
+// CallLog returns calls of all methods ordered by the sequence number.
+func (mock *MockI3) CallLog() []MockI3Call {
+	var log []MockI3Call
+
+	for _, c := range mock.Calls.Method1 {
+		log = append(log, MockI3Call{
+			Method:  "Method1",
+			Seq:     c.Seq,
+			Start:   c.Start,
+			End:     c.End,
+			Args:    []any{c.a, c.b},
+			Results: []any{c.r0, c.r1},
+		})
+	}
+
+	for _, c := range mock.Calls.Method2 {
+		log = append(log, MockI3Call{
+			Method:  "Method2",
+			Seq:     c.Seq,
+			Start:   c.Start,
+			End:     c.End,
+			Args:    []any{c.s},
+			Results: []any{},
+		})
+	}
+
+	slices.SortFunc(log, func(a, b MockI3Call) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+
+	return log
+}
+
+// Method1Returns queues results returned by the next calls of Method1 while Method1Func is nil.
+func (mock *MockI3) Method1Returns(r0 S1, r1 error) {
+	mock.returns.Method1.queue = append(mock.returns.Method1.queue, struct {
+		r0 S1
+		r1 error
+	}{r0, r1})
+}
+
+// Method1ReturnsOnCall sets results returned by the i-th (zero-based) call of Method1 while Method1Func is nil.
+func (mock *MockI3) Method1ReturnsOnCall(i int, r0 S1, r1 error) {
+	if mock.returns.Method1.onCall == nil {
+		mock.returns.Method1.onCall = map[int]struct {
+			r0 S1
+			r1 error
+		}{}
+	}
+
+	mock.returns.Method1.onCall[i] = struct {
+		r0 S1
+		r1 error
+	}{r0, r1}
+}
+
+// queuedMethod1 returns func returning results queued for the call of Method1.
+// Returns nil if no results are set for the call and none are queued by Method1Returns.
+func (mock *MockI3) queuedMethod1(call int) func(a int, b string) (S1, error) {
+	returns := &mock.returns.Method1
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method Method1 are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func(a int, b string) (S1, error) {
+		return res.r0, res.r1
+	}
+}
+
+func (mock *MockI3) Method1(a int, b string) (S1, error) {
+	fn := mock.Method1Func
+	if fn == nil {
+		fn = mock.queuedMethod1(len(mock.Calls.Method1))
+	}
+	if fn == nil {
+		if mock.impl == nil {
+			panic("nil method Method1 is called!")
+		}
+
+		fn = mock.impl.Method1
+	}
+
+	mock.seq++
+	seq := mock.seq
+	start := time.Now()
+
+	r0, r1 := fn(a, b)
+
+	end := time.Now()
+
+	callInfo := struct {
+		a     int
+		b     string
+		r0    S1
+		r1    error
+		Seq   uint64
+		Start time.Time
+		End   time.Time
+	}{a, b, r0, r1, seq, start, end}
+
+	mock.Calls.Method1 = append(mock.Calls.Method1, callInfo)
+
+	return r0, r1
+}
+
+func (mock *MockI3) Method2(s *S4[string]) {
+	fn := mock.Method2Func
+	if fn == nil {
+		if mock.impl == nil {
+			panic("nil method Method2 is called!")
+		}
+
+		fn = mock.impl.Method2
+	}
+
+	mock.seq++
+	seq := mock.seq
+	start := time.Now()
+
+	fn(s)
+
+	end := time.Now()
+
+	callInfo := struct {
+		s     *S4[string]
+		Seq   uint64
+		Start time.Time
+		End   time.Time
+	}{s, seq, start, end}
+
+	mock.Calls.Method2 = append(mock.Calls.Method2, callInfo)
+}
@@ -0,0 +1,292 @@
+// Code generated by "genpls:metrics"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"sync"
+	"time"
+)
+
+// MetricsRecorder records calls of the methods wrapped by the metrics wrappers.
+type MetricsRecorder interface {
+	// RecordCall records a call of the interface's method which lasted d and returned err.
+	// Err is always nil for methods without error result.
+	RecordCall(iface, method string, d time.Duration, err error)
+}
+
+// expvarBuckets are the upper bounds of the latency histograms buckets.
+var expvarBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// ExpvarRecorder is a MetricsRecorder publishing metrics with expvar.
+//
+// Calls and errors are counted by the "<iface>.<method>" keys.
+// Latency histograms count calls lasting less or equal to the buckets bounds,
+// the total count and sum of durations in seconds.
+type ExpvarRecorder struct {
+	mu      sync.Mutex
+	calls   *expvar.Map
+	errors  *expvar.Map
+	latency *expvar.Map
+}
+
+// NewExpvarRecorder returns a new *ExpvarRecorder publishing the name_calls, name_errors
+// and name_latency maps. It panics if the maps are already published.
+func NewExpvarRecorder(name string) *ExpvarRecorder {
+	return &ExpvarRecorder{
+		calls:   expvar.NewMap(name + "_calls"),
+		errors:  expvar.NewMap(name + "_errors"),
+		latency: expvar.NewMap(name + "_latency"),
+	}
+}
+
+func (r *ExpvarRecorder) RecordCall(iface, method string, d time.Duration, err error) {
+	key := iface + "." + method
+
+	r.calls.Add(key, 1)
+
+	if err != nil {
+		r.errors.Add(key, 1)
+	}
+
+	histogram := r.histogram(key)
+
+	for _, bound := range expvarBuckets {
+		if d <= bound {
+			histogram.Add("le_"+bound.String(), 1)
+		}
+	}
+
+	histogram.Add("count", 1)
+	histogram.AddFloat("sum", d.Seconds())
+}
+
+func (r *ExpvarRecorder) histogram(key string) *expvar.Map {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if histogram, ok := r.latency.Get(key).(*expvar.Map); ok {
+		return histogram
+	}
+
+	histogram := new(expvar.Map)
+	r.latency.Set(key, histogram)
+
+	return histogram
+}
+
+// *MetricsI3 implements I3.
+type MetricsI3 struct {
+	v        I3
+	recorder MetricsRecorder
+}
+
+// NewMetricsI3 returns a new *MetricsI3 recording calls of v with recorder.
+func NewMetricsI3(v I3, recorder MetricsRecorder) (*MetricsI3, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if recorder == nil {
+		return nil, errors.New("recorder is nil")
+	}
+
+	return &MetricsI3{
+		v:        v,
+		recorder: recorder,
+	}, nil
+}
+
+func (w *MetricsI3) Method1(a int, b string) (r0 S1, r1 error) {
+	callStart := time.Now()
+
+	r0, r1 = w.v.Method1(a, b)
+	w.recorder.RecordCall("I3", "Method1", time.Since(callStart), r1)
+
+	return r0, r1
+}
+
+func (w *MetricsI3) Method2(s *S4[string]) {
+	callStart := time.Now()
+
+	w.v.Method2(s)
+	w.recorder.RecordCall("I3", "Method2", time.Since(callStart), nil)
+}
+
+// *MetricsRepo implements Repo.
+type MetricsRepo struct {
+	v        Repo
+	recorder MetricsRecorder
+}
+
+// NewMetricsRepo returns a new *MetricsRepo recording calls of v with recorder.
+func NewMetricsRepo(v Repo, recorder MetricsRecorder) (*MetricsRepo, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if recorder == nil {
+		return nil, errors.New("recorder is nil")
+	}
+
+	return &MetricsRepo{
+		v:        v,
+		recorder: recorder,
+	}, nil
+}
+
+func (w *MetricsRepo) GetItem(ctx context.Context, id string) (r0 Item, r1 error) {
+	callStart := time.Now()
+
+	r0, r1 = w.v.GetItem(ctx, id)
+	w.recorder.RecordCall("Repo", "GetItem", time.Since(callStart), r1)
+
+	return r0, r1
+}
+
+func (w *MetricsRepo) PutItem(ctx context.Context, item Item) (r0 error) {
+	callStart := time.Now()
+
+	r0 = w.v.PutItem(ctx, item)
+	w.recorder.RecordCall("Repo", "PutItem", time.Since(callStart), r0)
+
+	return r0
+}
+
+func (w *MetricsRepo) ListItems(ctx context.Context) (r0 []Item, r1 error) {
+	callStart := time.Now()
+
+	r0, r1 = w.v.ListItems(ctx)
+	w.recorder.RecordCall("Repo", "ListItems", time.Since(callStart), r1)
+
+	return r0, r1
+}
+
+func (w *MetricsRepo) DeleteItem(ctx context.Context, id string) (r0 error) {
+	callStart := time.Now()
+
+	r0 = w.v.DeleteItem(ctx, id)
+	w.recorder.RecordCall("Repo", "DeleteItem", time.Since(callStart), r0)
+
+	return r0
+}
+
+func (w *MetricsRepo) Count(ctx context.Context) (r0 int, r1 error) {
+	callStart := time.Now()
+
+	r0, r1 = w.v.Count(ctx)
+	w.recorder.RecordCall("Repo", "Count", time.Since(callStart), r1)
+
+	return r0, r1
+}
+
+func (w *MetricsRepo) Close() (r0 error) {
+	callStart := time.Now()
+
+	r0 = w.v.Close()
+	w.recorder.RecordCall("Repo", "Close", time.Since(callStart), r0)
+
+	return r0
+}
+
+// *MetricsClash implements Clash.
+type MetricsClash struct {
+	v        Clash
+	recorder MetricsRecorder
+}
+
+// NewMetricsClash returns a new *MetricsClash recording calls of v with recorder.
+func NewMetricsClash(v Clash, recorder MetricsRecorder) (*MetricsClash, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if recorder == nil {
+		return nil, errors.New("recorder is nil")
+	}
+
+	return &MetricsClash{
+		v:        v,
+		recorder: recorder,
+	}, nil
+}
+
+func (w *MetricsClash) A(ctx context.Context, r0 int) (r0_1 int, r1 error) {
+	callStart := time.Now()
+
+	r0_1, r1 = w.v.A(ctx, r0)
+	w.recorder.RecordCall("Clash", "A", time.Since(callStart), r1)
+
+	return r0_1, r1
+}
+
+func (w *MetricsClash) B(res string, returns int, ok bool, call int) (r0 string, r1 error) {
+	callStart := time.Now()
+
+	r0, r1 = w.v.B(res, returns, ok, call)
+	w.recorder.RecordCall("Clash", "B", time.Since(callStart), r1)
+
+	return r0, r1
+}
+
+// *MetricsNamedResults implements NamedResults.
+type MetricsNamedResults struct {
+	v        NamedResults
+	recorder MetricsRecorder
+}
+
+// NewMetricsNamedResults returns a new *MetricsNamedResults recording calls of v with recorder.
+func NewMetricsNamedResults(v NamedResults, recorder MetricsRecorder) (*MetricsNamedResults, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if recorder == nil {
+		return nil, errors.New("recorder is nil")
+	}
+
+	return &MetricsNamedResults{
+		v:        v,
+		recorder: recorder,
+	}, nil
+}
+
+func (w *MetricsNamedResults) A() (r0 int) {
+	callStart := time.Now()
+
+	r0 = w.v.A()
+	w.recorder.RecordCall("NamedResults", "A", time.Since(callStart), nil)
+
+	return r0
+}
+
+func (w *MetricsNamedResults) B(ctx context.Context, id string) (r0 string, r1 error) {
+	callStart := time.Now()
+
+	r0, r1 = w.v.B(ctx, id)
+	w.recorder.RecordCall("NamedResults", "B", time.Since(callStart), r1)
+
+	return r0, r1
+}
+
+func (w *MetricsNamedResults) C() (r0_1 int, r1 error) {
+	callStart := time.Now()
+
+	r0_1, r1 = w.v.C()
+	w.recorder.RecordCall("NamedResults", "C", time.Since(callStart), r1)
+
+	return r0_1, r1
+}
@@ -0,0 +1,41 @@
+// Code generated by "genpls:metrics"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// *MetricsShared implements Shared.
+type MetricsShared struct {
+	v        Shared
+	recorder MetricsRecorder
+}
+
+// NewMetricsShared returns a new *MetricsShared recording calls of v with recorder.
+func NewMetricsShared(v Shared, recorder MetricsRecorder) (*MetricsShared, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if recorder == nil {
+		return nil, errors.New("recorder is nil")
+	}
+
+	return &MetricsShared{
+		v:        v,
+		recorder: recorder,
+	}, nil
+}
+
+func (w *MetricsShared) Get(ctx context.Context, id string) (r0 string, r1 error) {
+	callStart := time.Now()
+
+	r0, r1 = w.v.Get(ctx, id)
+	w.recorder.RecordCall("Shared", "Get", time.Since(callStart), r1)
+
+	return r0, r1
+}
@@ -0,0 +1,153 @@
+package parse
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordedCall is the call recorded by the recordingRecorder.
+type recordedCall struct {
+	iface, method string
+	err           error
+}
+
+// recordingRecorder records the calls.
+type recordingRecorder struct {
+	mu    sync.Mutex
+	calls []recordedCall
+}
+
+func (r *recordingRecorder) RecordCall(iface, method string, d time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if d < 0 {
+		panic("negative duration")
+	}
+
+	r.calls = append(r.calls, recordedCall{iface: iface, method: method, err: err})
+}
+
+var errMetricsCall = errors.New("call failed")
+
+func TestMetricsShared_recordCall(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingRecorder{}
+
+	w, err := NewMetricsShared(sharedFunc(func(_ context.Context, id string) (string, error) {
+		if id == "fail" {
+			return "", errMetricsCall
+		}
+
+		return id, nil
+	}), recorder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := w.Get(context.Background(), "id"); got != "id" || err != nil {
+		t.Fatalf("got %q, %v, want the wrapped result", got, err)
+	}
+
+	if _, err := w.Get(context.Background(), "fail"); !errors.Is(err, errMetricsCall) {
+		t.Fatalf("got error %v, want %v", err, errMetricsCall)
+	}
+
+	want := []recordedCall{
+		{iface: "Shared", method: "Get", err: nil},
+		{iface: "Shared", method: "Get", err: errMetricsCall},
+	}
+
+	if !reflect.DeepEqual(recorder.calls, want) {
+		t.Fatalf("got recorded %+v, want %+v", recorder.calls, want)
+	}
+}
+
+func TestMetricsI3_recordCall(t *testing.T) {
+	t.Parallel()
+
+	recorder := &recordingRecorder{}
+
+	w, err := NewMetricsI3(i3Impl{}, recorder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = w.Method1(1, "b")
+	w.Method2(nil)
+
+	// The method without error result is recorded with nil error.
+	want := []recordedCall{
+		{iface: "I3", method: "Method1"},
+		{iface: "I3", method: "Method2"},
+	}
+
+	if !reflect.DeepEqual(recorder.calls, want) {
+		t.Fatalf("got recorded %+v, want %+v", recorder.calls, want)
+	}
+}
+
+// expvarRuns names the maps published by the test runs uniquely, expvar maps can't be unpublished.
+var expvarRuns atomic.Int64
+
+func TestExpvarRecorder(t *testing.T) {
+	t.Parallel()
+
+	name := fmt.Sprintf("test_metrics_%d", expvarRuns.Add(1))
+	recorder := NewExpvarRecorder(name)
+
+	w, err := NewMetricsShared(sharedFunc(func(_ context.Context, id string) (string, error) {
+		if id == "fail" {
+			return "", errMetricsCall
+		}
+
+		return id, nil
+	}), recorder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"a", "fail", "b"} {
+		_, _ = w.Get(context.Background(), id)
+	}
+
+	value := func(name, key string) int64 {
+		t.Helper()
+
+		v, ok := expvar.Get(name).(*expvar.Map).Get(key).(*expvar.Int)
+		if !ok {
+			t.Fatalf("%s[%s] is not published", name, key)
+		}
+
+		return v.Value()
+	}
+
+	if calls := value(name+"_calls", "Shared.Get"); calls != 3 {
+		t.Fatalf("got %d calls, want 3", calls)
+	}
+
+	if errs := value(name+"_errors", "Shared.Get"); errs != 1 {
+		t.Fatalf("got %d errors, want 1", errs)
+	}
+
+	histogram, ok := expvar.Get(name + "_latency").(*expvar.Map).Get("Shared.Get").(*expvar.Map)
+	if !ok {
+		t.Fatal("the latency of Shared.Get is not published")
+	}
+
+	if count := histogram.Get("count").(*expvar.Int).Value(); count != 3 {
+		t.Fatalf("got the latency count %d, want 3", count)
+	}
+
+	if le := histogram.Get("le_10s").(*expvar.Int).Value(); le != 3 {
+		t.Fatalf("got %d calls lasting up to 10s, want 3", le)
+	}
+}
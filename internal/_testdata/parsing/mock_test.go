@@ -0,0 +1,92 @@
+package parse
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type i3Impl struct{}
+
+func (i3Impl) Method1(a int, b string) (S1, error) { return S1{S1Field1: b, S1Field2: a}, nil }
+
+func (i3Impl) Method2(s *S4[string]) {}
+
+func TestMockI3_spyReturnsOnCall(t *testing.T) {
+	t.Parallel()
+
+	errOnCall := errors.New("on call")
+
+	mock := NewMockI3(i3Impl{})
+	mock.Method1ReturnsOnCall(1, S1{}, errOnCall)
+
+	for i, want := range []error{nil, errOnCall, nil} {
+		got, err := mock.Method1(i, "impl")
+		if !errors.Is(err, want) {
+			t.Fatalf("call %d: got error %v, want %v", i, err, want)
+		}
+
+		if want == nil && got.S1Field2 != i {
+			t.Fatalf("call %d: got %+v, want the impl result", i, got)
+		}
+	}
+}
+
+func TestMockI3_queueExhausted(t *testing.T) {
+	t.Parallel()
+
+	mock := NewMockI3(i3Impl{})
+	mock.Method1Returns(S1{S1Field1: "queued"}, nil)
+
+	if got, _ := mock.Method1(0, "impl"); got.S1Field1 != "queued" {
+		t.Fatalf("got %+v, want the queued result", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("the exhausted queue must panic")
+		}
+	}()
+
+	_, _ = mock.Method1(1, "impl")
+}
+
+func TestMockI3_callLog(t *testing.T) {
+	t.Parallel()
+
+	s := &S4[string]{S4Field: "s"}
+
+	mock := NewMockI3(i3Impl{})
+
+	_, _ = mock.Method1(1, "a")
+	mock.Method2(s)
+	_, _ = mock.Method1(2, "b")
+
+	log := mock.CallLog()
+	if len(log) != 3 {
+		t.Fatalf("got %d calls, want 3", len(log))
+	}
+
+	want := []MockI3Call{
+		{Method: "Method1", Seq: 1, Args: []any{1, "a"}, Results: []any{S1{S1Field1: "a", S1Field2: 1}, nil}},
+		{Method: "Method2", Seq: 2, Args: []any{s}, Results: []any{}},
+		{Method: "Method1", Seq: 3, Args: []any{2, "b"}, Results: []any{S1{S1Field1: "b", S1Field2: 2}, nil}},
+	}
+
+	for i, call := range log {
+		// The calls are ordered by the sequence numbers and timestamps across the methods.
+		if call.Start.IsZero() || call.End.Before(call.Start) {
+			t.Fatalf("call %d: got start %v and end %v", i, call.Start, call.End)
+		}
+
+		if i > 0 && call.Start.Before(log[i-1].End) {
+			t.Fatalf("call %d: started at %v before the previous call ended at %v", i, call.Start, log[i-1].End)
+		}
+
+		call.Start, call.End = want[i].Start, want[i].End
+
+		if !reflect.DeepEqual(call, want[i]) {
+			t.Fatalf("call %d: got %+v, want %+v", i, call, want[i])
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// Code generated by "genpls:mock"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package mocks
+
+import (
+	"cmp"
+	"parse"
+	"slices"
+	"time"
+)
+
+// *MockCalc implements Calc.
+type MockCalc[T parse.Number, S ~[]T, K interface {
+	String() string
+	comparable
+}] struct {
+	SumFunc    func(values S) T
+	LookupFunc func(key K) (T, error)
+
+	Calls struct {
+		Sum []struct {
+			values S
+			r0     T
+			Seq    uint64
+			Start  time.Time
+			End    time.Time
+		}
+		Lookup []struct {
+			key   K
+			r0    T
+			r1    error
+			Seq   uint64
+			Start time.Time
+			End   time.Time
+		}
+	}
+
+	seq uint64
+
+	returns struct {
+		Sum struct {
+			queue []struct {
+				r0 T
+			}
+			onCall map[int]struct {
+				r0 T
+			}
+			next int
+		}
+		Lookup struct {
+			queue []struct {
+				r0 T
+				r1 error
+			}
+			onCall map[int]struct {
+				r0 T
+				r1 error
+			}
+			next int
+		}
+	}
+}
+
+// MockCalcCall is a record of the MockCalc's method call.
+type MockCalcCall struct {
+	Method  string
+	Seq     uint64
+	Start   time.Time
+	End     time.Time
+	Args    []any
+	Results []any
+}
+
+// CallLog returns calls of all methods ordered by the sequence number.
+func (mock *MockCalc[T, S, K]) CallLog() []MockCalcCall {
+	var log []MockCalcCall
+
+	for _, c := range mock.Calls.Sum {
+		log = append(log, MockCalcCall{
+			Method:  "Sum",
+			Seq:     c.Seq,
+			Start:   c.Start,
+			End:     c.End,
+			Args:    []any{c.values},
+			Results: []any{c.r0},
+		})
+	}
+
+	for _, c := range mock.Calls.Lookup {
+		log = append(log, MockCalcCall{
+			Method:  "Lookup",
+			Seq:     c.Seq,
+			Start:   c.Start,
+			End:     c.End,
+			Args:    []any{c.key},
+			Results: []any{c.r0, c.r1},
+		})
+	}
+
+	slices.SortFunc(log, func(a, b MockCalcCall) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+
+	return log
+}
+
+// SumReturns queues results returned by the next calls of Sum while SumFunc is nil.
+func (mock *MockCalc[T, S, K]) SumReturns(r0 T) {
+	mock.returns.Sum.queue = append(mock.returns.Sum.queue, struct {
+		r0 T
+	}{r0})
+}
+
+// SumReturnsOnCall sets results returned by the i-th (zero-based) call of Sum while SumFunc is nil.
+func (mock *MockCalc[T, S, K]) SumReturnsOnCall(i int, r0 T) {
+	if mock.returns.Sum.onCall == nil {
+		mock.returns.Sum.onCall = map[int]struct {
+			r0 T
+		}{}
+	}
+
+	mock.returns.Sum.onCall[i] = struct {
+		r0 T
+	}{r0}
+}
+
+// queuedSum returns func returning results queued for the call of Sum.
+// Returns nil if no results are set for the call and none are queued by SumReturns.
+func (mock *MockCalc[T, S, K]) queuedSum(call int) func(values S) T {
+	returns := &mock.returns.Sum
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method Sum are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func(values S) T {
+		return res.r0
+	}
+}
+
+func (mock *MockCalc[T, S, K]) Sum(values S) T {
+	fn := mock.SumFunc
+	if fn == nil {
+		fn = mock.queuedSum(len(mock.Calls.Sum))
+	}
+	if fn == nil {
+		panic("nil method Sum is called!")
+	}
+
+	mock.seq++
+	seq := mock.seq
+	start := time.Now()
+
+	r0 := fn(values)
+
+	end := time.Now()
+
+	callInfo := struct {
+		values S
+		r0     T
+		Seq    uint64
+		Start  time.Time
+		End    time.Time
+	}{values, r0, seq, start, end}
+
+	mock.Calls.Sum = append(mock.Calls.Sum, callInfo)
+
+	return r0
+}
+
+// LookupReturns queues results returned by the next calls of Lookup while LookupFunc is nil.
+func (mock *MockCalc[T, S, K]) LookupReturns(r0 T, r1 error) {
+	mock.returns.Lookup.queue = append(mock.returns.Lookup.queue, struct {
+		r0 T
+		r1 error
+	}{r0, r1})
+}
+
+// LookupReturnsOnCall sets results returned by the i-th (zero-based) call of Lookup while LookupFunc is nil.
+func (mock *MockCalc[T, S, K]) LookupReturnsOnCall(i int, r0 T, r1 error) {
+	if mock.returns.Lookup.onCall == nil {
+		mock.returns.Lookup.onCall = map[int]struct {
+			r0 T
+			r1 error
+		}{}
+	}
+
+	mock.returns.Lookup.onCall[i] = struct {
+		r0 T
+		r1 error
+	}{r0, r1}
+}
+
+// queuedLookup returns func returning results queued for the call of Lookup.
+// Returns nil if no results are set for the call and none are queued by LookupReturns.
+func (mock *MockCalc[T, S, K]) queuedLookup(call int) func(key K) (T, error) {
+	returns := &mock.returns.Lookup
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method Lookup are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func(key K) (T, error) {
+		return res.r0, res.r1
+	}
+}
+
+func (mock *MockCalc[T, S, K]) Lookup(key K) (T, error) {
+	fn := mock.LookupFunc
+	if fn == nil {
+		fn = mock.queuedLookup(len(mock.Calls.Lookup))
+	}
+	if fn == nil {
+		panic("nil method Lookup is called!")
+	}
+
+	mock.seq++
+	seq := mock.seq
+	start := time.Now()
+
+	r0, r1 := fn(key)
+
+	end := time.Now()
+
+	callInfo := struct {
+		key   K
+		r0    T
+		r1    error
+		Seq   uint64
+		Start time.Time
+		End   time.Time
+	}{key, r0, r1, seq, start, end}
+
+	mock.Calls.Lookup = append(mock.Calls.Lookup, callInfo)
+
+	return r0, r1
+}
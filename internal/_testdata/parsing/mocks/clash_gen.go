@@ -0,0 +1,270 @@
+// Code generated by "genpls:mock"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package mocks
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+)
+
+// *MockClash implements Clash.
+type MockClash struct {
+	AFunc func(ctx context.Context, r0 int) (int, error)
+	BFunc func(res_1 string, returns_1 int, ok_1 bool, call_1 int) (string, error)
+
+	Calls struct {
+		A []struct {
+			ctx   context.Context
+			r0    int
+			r0_1  int
+			r1    error
+			Seq   uint64
+			Start time.Time
+			End   time.Time
+		}
+		B []struct {
+			res_1     string
+			returns_1 int
+			ok_1      bool
+			call_1    int
+			r0        string
+			r1        error
+			Seq       uint64
+			Start     time.Time
+			End       time.Time
+		}
+	}
+
+	seq uint64
+
+	returns struct {
+		A struct {
+			queue []struct {
+				r0_1 int
+				r1   error
+			}
+			onCall map[int]struct {
+				r0_1 int
+				r1   error
+			}
+			next int
+		}
+		B struct {
+			queue []struct {
+				r0 string
+				r1 error
+			}
+			onCall map[int]struct {
+				r0 string
+				r1 error
+			}
+			next int
+		}
+	}
+}
+
+// MockClashCall is a record of the MockClash's method call.
+type MockClashCall struct {
+	Method  string
+	Seq     uint64
+	Start   time.Time
+	End     time.Time
+	Args    []any
+	Results []any
+}
+
+// CallLog returns calls of all methods ordered by the sequence number.
+func (mock *MockClash) CallLog() []MockClashCall {
+	var log []MockClashCall
+
+	for _, c := range mock.Calls.A {
+		log = append(log, MockClashCall{
+			Method:  "A",
+			Seq:     c.Seq,
+			Start:   c.Start,
+			End:     c.End,
+			Args:    []any{c.ctx, c.r0},
+			Results: []any{c.r0_1, c.r1},
+		})
+	}
+
+	for _, c := range mock.Calls.B {
+		log = append(log, MockClashCall{
+			Method:  "B",
+			Seq:     c.Seq,
+			Start:   c.Start,
+			End:     c.End,
+			Args:    []any{c.res_1, c.returns_1, c.ok_1, c.call_1},
+			Results: []any{c.r0, c.r1},
+		})
+	}
+
+	slices.SortFunc(log, func(a, b MockClashCall) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+
+	return log
+}
+
+// AReturns queues results returned by the next calls of A while AFunc is nil.
+func (mock *MockClash) AReturns(r0_1 int, r1 error) {
+	mock.returns.A.queue = append(mock.returns.A.queue, struct {
+		r0_1 int
+		r1   error
+	}{r0_1, r1})
+}
+
+// AReturnsOnCall sets results returned by the i-th (zero-based) call of A while AFunc is nil.
+func (mock *MockClash) AReturnsOnCall(i int, r0_1 int, r1 error) {
+	if mock.returns.A.onCall == nil {
+		mock.returns.A.onCall = map[int]struct {
+			r0_1 int
+			r1   error
+		}{}
+	}
+
+	mock.returns.A.onCall[i] = struct {
+		r0_1 int
+		r1   error
+	}{r0_1, r1}
+}
+
+// queuedA returns func returning results queued for the call of A.
+// Returns nil if no results are set for the call and none are queued by AReturns.
+func (mock *MockClash) queuedA(call int) func(ctx context.Context, r0 int) (int, error) {
+	returns := &mock.returns.A
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method A are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func(ctx context.Context, r0 int) (int, error) {
+		return res.r0_1, res.r1
+	}
+}
+
+func (mock *MockClash) A(ctx context.Context, r0 int) (int, error) {
+	fn := mock.AFunc
+	if fn == nil {
+		fn = mock.queuedA(len(mock.Calls.A))
+	}
+	if fn == nil {
+		panic("nil method A is called!")
+	}
+
+	mock.seq++
+	seq := mock.seq
+	start := time.Now()
+
+	r0_1, r1 := fn(ctx, r0)
+
+	end := time.Now()
+
+	callInfo := struct {
+		ctx   context.Context
+		r0    int
+		r0_1  int
+		r1    error
+		Seq   uint64
+		Start time.Time
+		End   time.Time
+	}{ctx, r0, r0_1, r1, seq, start, end}
+
+	mock.Calls.A = append(mock.Calls.A, callInfo)
+
+	return r0_1, r1
+}
+
+// BReturns queues results returned by the next calls of B while BFunc is nil.
+func (mock *MockClash) BReturns(r0 string, r1 error) {
+	mock.returns.B.queue = append(mock.returns.B.queue, struct {
+		r0 string
+		r1 error
+	}{r0, r1})
+}
+
+// BReturnsOnCall sets results returned by the i-th (zero-based) call of B while BFunc is nil.
+func (mock *MockClash) BReturnsOnCall(i int, r0 string, r1 error) {
+	if mock.returns.B.onCall == nil {
+		mock.returns.B.onCall = map[int]struct {
+			r0 string
+			r1 error
+		}{}
+	}
+
+	mock.returns.B.onCall[i] = struct {
+		r0 string
+		r1 error
+	}{r0, r1}
+}
+
+// queuedB returns func returning results queued for the call of B.
+// Returns nil if no results are set for the call and none are queued by BReturns.
+func (mock *MockClash) queuedB(call int) func(res_1 string, returns_1 int, ok_1 bool, call_1 int) (string, error) {
+	returns := &mock.returns.B
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method B are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func(res_1 string, returns_1 int, ok_1 bool, call_1 int) (string, error) {
+		return res.r0, res.r1
+	}
+}
+
+func (mock *MockClash) B(res_1 string, returns_1 int, ok_1 bool, call_1 int) (string, error) {
+	fn := mock.BFunc
+	if fn == nil {
+		fn = mock.queuedB(len(mock.Calls.B))
+	}
+	if fn == nil {
+		panic("nil method B is called!")
+	}
+
+	mock.seq++
+	seq := mock.seq
+	start := time.Now()
+
+	r0, r1 := fn(res_1, returns_1, ok_1, call_1)
+
+	end := time.Now()
+
+	callInfo := struct {
+		res_1     string
+		returns_1 int
+		ok_1      bool
+		call_1    int
+		r0        string
+		r1        error
+		Seq       uint64
+		Start     time.Time
+		End       time.Time
+	}{res_1, returns_1, ok_1, call_1, r0, r1, seq, start, end}
+
+	mock.Calls.B = append(mock.Calls.B, callInfo)
+
+	return r0, r1
+}
@@ -0,0 +1,326 @@
+// Code generated by "genpls:mock"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package mocks
+
+import (
+	"parse"
+)
+
+// *MockEmbedded implements Embedded.
+type MockEmbedded struct {
+	GetFunc   func(key string) (parse.S1, error)
+	ReadFunc  func(p []byte) (n int, err error)
+	CloseFunc func() error
+	NameFunc  func() string
+
+	Calls struct {
+		Get []struct {
+			key string
+		}
+		Read []struct {
+			p []byte
+		}
+		Close []struct {
+		}
+		Name []struct {
+		}
+	}
+
+	returns struct {
+		Get struct {
+			queue []struct {
+				r0 parse.S1
+				r1 error
+			}
+			onCall map[int]struct {
+				r0 parse.S1
+				r1 error
+			}
+			next int
+		}
+		Read struct {
+			queue []struct {
+				r0 int
+				r1 error
+			}
+			onCall map[int]struct {
+				r0 int
+				r1 error
+			}
+			next int
+		}
+		Close struct {
+			queue []struct {
+				r0 error
+			}
+			onCall map[int]struct {
+				r0 error
+			}
+			next int
+		}
+		Name struct {
+			queue []struct {
+				r0 string
+			}
+			onCall map[int]struct {
+				r0 string
+			}
+			next int
+		}
+	}
+}
+
+// GetReturns queues results returned by the next calls of Get while GetFunc is nil.
+func (mock *MockEmbedded) GetReturns(r0 parse.S1, r1 error) {
+	mock.returns.Get.queue = append(mock.returns.Get.queue, struct {
+		r0 parse.S1
+		r1 error
+	}{r0, r1})
+}
+
+// GetReturnsOnCall sets results returned by the i-th (zero-based) call of Get while GetFunc is nil.
+func (mock *MockEmbedded) GetReturnsOnCall(i int, r0 parse.S1, r1 error) {
+	if mock.returns.Get.onCall == nil {
+		mock.returns.Get.onCall = map[int]struct {
+			r0 parse.S1
+			r1 error
+		}{}
+	}
+
+	mock.returns.Get.onCall[i] = struct {
+		r0 parse.S1
+		r1 error
+	}{r0, r1}
+}
+
+// queuedGet returns func returning results queued for the call of Get.
+// Returns nil if no results are set for the call and none are queued by GetReturns.
+func (mock *MockEmbedded) queuedGet(call int) func(key string) (parse.S1, error) {
+	returns := &mock.returns.Get
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method Get are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func(key string) (parse.S1, error) {
+		return res.r0, res.r1
+	}
+}
+
+// Get returns the value stored by the key.
+func (mock *MockEmbedded) Get(key string) (parse.S1, error) {
+	fn := mock.GetFunc
+	if fn == nil {
+		fn = mock.queuedGet(len(mock.Calls.Get))
+	}
+	if fn == nil {
+		panic("nil method Get is called!")
+	}
+
+	callInfo := struct {
+		key string
+	}{key}
+
+	mock.Calls.Get = append(mock.Calls.Get, callInfo)
+
+	return fn(key)
+}
+
+// ReadReturns queues results returned by the next calls of Read while ReadFunc is nil.
+func (mock *MockEmbedded) ReadReturns(r0 int, r1 error) {
+	mock.returns.Read.queue = append(mock.returns.Read.queue, struct {
+		r0 int
+		r1 error
+	}{r0, r1})
+}
+
+// ReadReturnsOnCall sets results returned by the i-th (zero-based) call of Read while ReadFunc is nil.
+func (mock *MockEmbedded) ReadReturnsOnCall(i int, r0 int, r1 error) {
+	if mock.returns.Read.onCall == nil {
+		mock.returns.Read.onCall = map[int]struct {
+			r0 int
+			r1 error
+		}{}
+	}
+
+	mock.returns.Read.onCall[i] = struct {
+		r0 int
+		r1 error
+	}{r0, r1}
+}
+
+// queuedRead returns func returning results queued for the call of Read.
+// Returns nil if no results are set for the call and none are queued by ReadReturns.
+func (mock *MockEmbedded) queuedRead(call int) func(p []byte) (n int, err error) {
+	returns := &mock.returns.Read
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method Read are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func(p []byte) (n int, err error) {
+		return res.r0, res.r1
+	}
+}
+
+func (mock *MockEmbedded) Read(p []byte) (n int, err error) {
+	fn := mock.ReadFunc
+	if fn == nil {
+		fn = mock.queuedRead(len(mock.Calls.Read))
+	}
+	if fn == nil {
+		panic("nil method Read is called!")
+	}
+
+	callInfo := struct {
+		p []byte
+	}{p}
+
+	mock.Calls.Read = append(mock.Calls.Read, callInfo)
+
+	return fn(p)
+}
+
+// CloseReturns queues results returned by the next calls of Close while CloseFunc is nil.
+func (mock *MockEmbedded) CloseReturns(r0 error) {
+	mock.returns.Close.queue = append(mock.returns.Close.queue, struct {
+		r0 error
+	}{r0})
+}
+
+// CloseReturnsOnCall sets results returned by the i-th (zero-based) call of Close while CloseFunc is nil.
+func (mock *MockEmbedded) CloseReturnsOnCall(i int, r0 error) {
+	if mock.returns.Close.onCall == nil {
+		mock.returns.Close.onCall = map[int]struct {
+			r0 error
+		}{}
+	}
+
+	mock.returns.Close.onCall[i] = struct {
+		r0 error
+	}{r0}
+}
+
+// queuedClose returns func returning results queued for the call of Close.
+// Returns nil if no results are set for the call and none are queued by CloseReturns.
+func (mock *MockEmbedded) queuedClose(call int) func() error {
+	returns := &mock.returns.Close
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method Close are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func() error {
+		return res.r0
+	}
+}
+
+func (mock *MockEmbedded) Close() error {
+	fn := mock.CloseFunc
+	if fn == nil {
+		fn = mock.queuedClose(len(mock.Calls.Close))
+	}
+	if fn == nil {
+		panic("nil method Close is called!")
+	}
+
+	callInfo := struct {
+	}{}
+
+	mock.Calls.Close = append(mock.Calls.Close, callInfo)
+
+	return fn()
+}
+
+// NameReturns queues results returned by the next calls of Name while NameFunc is nil.
+func (mock *MockEmbedded) NameReturns(r0 string) {
+	mock.returns.Name.queue = append(mock.returns.Name.queue, struct {
+		r0 string
+	}{r0})
+}
+
+// NameReturnsOnCall sets results returned by the i-th (zero-based) call of Name while NameFunc is nil.
+func (mock *MockEmbedded) NameReturnsOnCall(i int, r0 string) {
+	if mock.returns.Name.onCall == nil {
+		mock.returns.Name.onCall = map[int]struct {
+			r0 string
+		}{}
+	}
+
+	mock.returns.Name.onCall[i] = struct {
+		r0 string
+	}{r0}
+}
+
+// queuedName returns func returning results queued for the call of Name.
+// Returns nil if no results are set for the call and none are queued by NameReturns.
+func (mock *MockEmbedded) queuedName(call int) func() string {
+	returns := &mock.returns.Name
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method Name are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func() string {
+		return res.r0
+	}
+}
+
+// Name returns the name.
+func (mock *MockEmbedded) Name() string {
+	fn := mock.NameFunc
+	if fn == nil {
+		fn = mock.queuedName(len(mock.Calls.Name))
+	}
+	if fn == nil {
+		panic("nil method Name is called!")
+	}
+
+	callInfo := struct {
+	}{}
+
+	mock.Calls.Name = append(mock.Calls.Name, callInfo)
+
+	return fn()
+}
@@ -1,48 +1,47 @@
 // Code generated by "genpls:mock"; DO NOT EDIT.
 // github.com/WinPooh32/genpls
 
-package parse
+package mocks
 
 // *MockI1 implements I1.
 type MockI1 struct {
-	IMethod1Func func ()
-	imethod2Func func ()
+	IMethod1Func func()
+	imethod2Func func()
 
-	Calls struct{
-		IMethod1 []struct{ 
-			 
+	Calls struct {
+		IMethod1 []struct {
 		}
-		imethod2 []struct{ 
-			 
+		imethod2 []struct {
 		}
 	}
 }
 
+// IMethod1 doc
 func (mock *MockI1) IMethod1() {
-	if mock.IMethod1Func == nil {
+	fn := mock.IMethod1Func
+	if fn == nil {
 		panic("nil method IMethod1 is called!")
 	}
 
-	callInfo := struct{ 
-			
-	} {  }
+	callInfo := struct {
+	}{}
 
 	mock.Calls.IMethod1 = append(mock.Calls.IMethod1, callInfo)
 
-	mock.IMethod1Func()
+	fn()
 }
 
+// imethod2 doc
 func (mock *MockI1) imethod2() {
-	if mock.imethod2Func == nil {
+	fn := mock.imethod2Func
+	if fn == nil {
 		panic("nil method imethod2 is called!")
 	}
 
-	callInfo := struct{ 
-			
-	} {  }
+	callInfo := struct {
+	}{}
 
 	mock.Calls.imethod2 = append(mock.Calls.imethod2, callInfo)
 
-	mock.imethod2Func()
+	fn()
 }
-
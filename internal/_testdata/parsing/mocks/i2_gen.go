@@ -1,78 +1,303 @@
 // Code generated by "genpls:mock"; DO NOT EDIT.
 // github.com/WinPooh32/genpls
 
-package parse
+package mocks
 
 import (
+	"cmp"
 	"go/types"
 	io_1 "io"
 	types_2 "parse/types"
+	"slices"
+	"time"
 )
 
 // *MockI2 implements I2.
 type MockI2[T any, U comparable, Q io_1.Reader] struct {
-	IMethod1Func func ()
-	IMethod3Func func (a int, b types_2.S1, c types_2.S2[string], d types_2.S2[*types.Package]) (types_2.S1, error)
-	imethod2Func func (t T) (u U)
+	IMethod1Func func()
+	imethod2Func func(t T) (u U)
+	IMethod3Func func(a int, b types_2.S1, c types_2.S2[string], d types_2.S2[*types.Package]) (types_2.S1, error)
 
-	Calls struct{
-		IMethod1 []struct{ 
-			 
+	Calls struct {
+		IMethod1 []struct {
+			Seq   uint64
+			Start time.Time
+			End   time.Time
 		}
-		IMethod3 []struct{ 
-			a int
-			b types_2.S1
-			c types_2.S2[string]
-			d types_2.S2[*types.Package] 
+		imethod2 []struct {
+			t     T
+			r0    U
+			Seq   uint64
+			Start time.Time
+			End   time.Time
 		}
-		imethod2 []struct{ 
-			t T 
+		IMethod3 []struct {
+			a     int
+			b     types_2.S1
+			c     types_2.S2[string]
+			d     types_2.S2[*types.Package]
+			r0    types_2.S1
+			r1    error
+			Seq   uint64
+			Start time.Time
+			End   time.Time
 		}
 	}
+
+	seq uint64
+
+	returns struct {
+		imethod2 struct {
+			queue []struct {
+				r0 U
+			}
+			onCall map[int]struct {
+				r0 U
+			}
+			next int
+		}
+		IMethod3 struct {
+			queue []struct {
+				r0 types_2.S1
+				r1 error
+			}
+			onCall map[int]struct {
+				r0 types_2.S1
+				r1 error
+			}
+			next int
+		}
+	}
+}
+
+// MockI2Call is a record of the MockI2's method call.
+type MockI2Call struct {
+	Method  string
+	Seq     uint64
+	Start   time.Time
+	End     time.Time
+	Args    []any
+	Results []any
+}
+
+// CallLog returns calls of all methods ordered by the sequence number.
+func (mock *MockI2[T, U, Q]) CallLog() []MockI2Call {
+	var log []MockI2Call
+
+	for _, c := range mock.Calls.IMethod1 {
+		log = append(log, MockI2Call{
+			Method:  "IMethod1",
+			Seq:     c.Seq,
+			Start:   c.Start,
+			End:     c.End,
+			Args:    []any{},
+			Results: []any{},
+		})
+	}
+
+	for _, c := range mock.Calls.imethod2 {
+		log = append(log, MockI2Call{
+			Method:  "imethod2",
+			Seq:     c.Seq,
+			Start:   c.Start,
+			End:     c.End,
+			Args:    []any{c.t},
+			Results: []any{c.r0},
+		})
+	}
+
+	for _, c := range mock.Calls.IMethod3 {
+		log = append(log, MockI2Call{
+			Method:  "IMethod3",
+			Seq:     c.Seq,
+			Start:   c.Start,
+			End:     c.End,
+			Args:    []any{c.a, c.b, c.c, c.d},
+			Results: []any{c.r0, c.r1},
+		})
+	}
+
+	slices.SortFunc(log, func(a, b MockI2Call) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+
+	return log
 }
 
 func (mock *MockI2[T, U, Q]) IMethod1() {
-	if mock.IMethod1Func == nil {
+	fn := mock.IMethod1Func
+	if fn == nil {
 		panic("nil method IMethod1 is called!")
 	}
 
-	callInfo := struct{ 
-			
-	} {  }
+	mock.seq++
+	seq := mock.seq
+	start := time.Now()
+
+	fn()
+
+	end := time.Now()
+
+	callInfo := struct {
+		Seq   uint64
+		Start time.Time
+		End   time.Time
+	}{seq, start, end}
 
 	mock.Calls.IMethod1 = append(mock.Calls.IMethod1, callInfo)
+}
 
-	mock.IMethod1Func()
+// imethod2Returns queues results returned by the next calls of imethod2 while imethod2Func is nil.
+func (mock *MockI2[T, U, Q]) imethod2Returns(r0 U) {
+	mock.returns.imethod2.queue = append(mock.returns.imethod2.queue, struct {
+		r0 U
+	}{r0})
 }
 
-func (mock *MockI2[T, U, Q]) IMethod3(a int, b types_2.S1, c types_2.S2[string], d types_2.S2[*types.Package]) (types_2.S1, error) {
-	if mock.IMethod3Func == nil {
-		panic("nil method IMethod3 is called!")
+// imethod2ReturnsOnCall sets results returned by the i-th (zero-based) call of imethod2 while imethod2Func is nil.
+func (mock *MockI2[T, U, Q]) imethod2ReturnsOnCall(i int, r0 U) {
+	if mock.returns.imethod2.onCall == nil {
+		mock.returns.imethod2.onCall = map[int]struct {
+			r0 U
+		}{}
 	}
 
-	callInfo := struct{ 
-			a int
-			b types_2.S1
-			c types_2.S2[string]
-			d types_2.S2[*types.Package]
-	} { a, b, c, d }
+	mock.returns.imethod2.onCall[i] = struct {
+		r0 U
+	}{r0}
+}
+
+// queuedimethod2 returns func returning results queued for the call of imethod2.
+// Returns nil if no results are set for the call and none are queued by imethod2Returns.
+func (mock *MockI2[T, U, Q]) queuedimethod2(call int) func(t T) (u U) {
+	returns := &mock.returns.imethod2
 
-	mock.Calls.IMethod3 = append(mock.Calls.IMethod3, callInfo)
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method imethod2 are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
 
-	return mock.IMethod3Func(a, b, c, d)
+	return func(t T) (u U) {
+		return res.r0
+	}
 }
 
 func (mock *MockI2[T, U, Q]) imethod2(t T) (u U) {
-	if mock.imethod2Func == nil {
+	fn := mock.imethod2Func
+	if fn == nil {
+		fn = mock.queuedimethod2(len(mock.Calls.imethod2))
+	}
+	if fn == nil {
 		panic("nil method imethod2 is called!")
 	}
 
-	callInfo := struct{ 
-			t T
-	} { t }
+	mock.seq++
+	seq := mock.seq
+	start := time.Now()
+
+	r0 := fn(t)
+
+	end := time.Now()
+
+	callInfo := struct {
+		t     T
+		r0    U
+		Seq   uint64
+		Start time.Time
+		End   time.Time
+	}{t, r0, seq, start, end}
 
 	mock.Calls.imethod2 = append(mock.Calls.imethod2, callInfo)
 
-	return mock.imethod2Func(t)
+	return r0
+}
+
+// IMethod3Returns queues results returned by the next calls of IMethod3 while IMethod3Func is nil.
+func (mock *MockI2[T, U, Q]) IMethod3Returns(r0 types_2.S1, r1 error) {
+	mock.returns.IMethod3.queue = append(mock.returns.IMethod3.queue, struct {
+		r0 types_2.S1
+		r1 error
+	}{r0, r1})
+}
+
+// IMethod3ReturnsOnCall sets results returned by the i-th (zero-based) call of IMethod3 while IMethod3Func is nil.
+func (mock *MockI2[T, U, Q]) IMethod3ReturnsOnCall(i int, r0 types_2.S1, r1 error) {
+	if mock.returns.IMethod3.onCall == nil {
+		mock.returns.IMethod3.onCall = map[int]struct {
+			r0 types_2.S1
+			r1 error
+		}{}
+	}
+
+	mock.returns.IMethod3.onCall[i] = struct {
+		r0 types_2.S1
+		r1 error
+	}{r0, r1}
+}
+
+// queuedIMethod3 returns func returning results queued for the call of IMethod3.
+// Returns nil if no results are set for the call and none are queued by IMethod3Returns.
+func (mock *MockI2[T, U, Q]) queuedIMethod3(call int) func(a int, b types_2.S1, c types_2.S2[string], d types_2.S2[*types.Package]) (types_2.S1, error) {
+	returns := &mock.returns.IMethod3
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method IMethod3 are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func(a int, b types_2.S1, c types_2.S2[string], d types_2.S2[*types.Package]) (types_2.S1, error) {
+		return res.r0, res.r1
+	}
 }
 
+func (mock *MockI2[T, U, Q]) IMethod3(a int, b types_2.S1, c types_2.S2[string], d types_2.S2[*types.Package]) (types_2.S1, error) {
+	fn := mock.IMethod3Func
+	if fn == nil {
+		fn = mock.queuedIMethod3(len(mock.Calls.IMethod3))
+	}
+	if fn == nil {
+		panic("nil method IMethod3 is called!")
+	}
+
+	mock.seq++
+	seq := mock.seq
+	start := time.Now()
+
+	r0, r1 := fn(a, b, c, d)
+
+	end := time.Now()
+
+	callInfo := struct {
+		a     int
+		b     types_2.S1
+		c     types_2.S2[string]
+		d     types_2.S2[*types.Package]
+		r0    types_2.S1
+		r1    error
+		Seq   uint64
+		Start time.Time
+		End   time.Time
+	}{a, b, c, d, r0, r1, seq, start, end}
+
+	mock.Calls.IMethod3 = append(mock.Calls.IMethod3, callInfo)
+
+	return r0, r1
+}
@@ -0,0 +1,140 @@
+// Code generated by "genpls:mock"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package mocks
+
+import (
+	"parse"
+)
+
+// *MockI3 implements I3.
+type MockI3 struct {
+	Method1Func func(a int, b string) (parse.S1, error)
+	Method2Func func(s *parse.S4[string])
+
+	Calls struct {
+		Method1 []struct {
+			a  int
+			b  string
+			r0 parse.S1
+			r1 error
+		}
+		Method2 []struct {
+			s *parse.S4[string]
+		}
+	}
+
+	impl parse.I3
+
+	returns struct {
+		Method1 struct {
+			queue []struct {
+				r0 parse.S1
+				r1 error
+			}
+			onCall map[int]struct {
+				r0 parse.S1
+				r1 error
+			}
+			next int
+		}
+	}
+}
+
+// NewMockI3 returns a new *MockI3 which calls impl for the methods with nil funcs.
+func NewMockI3(impl parse.I3) *MockI3 {
+	return &MockI3{impl: impl}
+}
+
+// Method1Returns queues results returned by the next calls of Method1 while Method1Func is nil.
+func (mock *MockI3) Method1Returns(r0 parse.S1, r1 error) {
+	mock.returns.Method1.queue = append(mock.returns.Method1.queue, struct {
+		r0 parse.S1
+		r1 error
+	}{r0, r1})
+}
+
+// Method1ReturnsOnCall sets results returned by the i-th (zero-based) call of Method1 while Method1Func is nil.
+func (mock *MockI3) Method1ReturnsOnCall(i int, r0 parse.S1, r1 error) {
+	if mock.returns.Method1.onCall == nil {
+		mock.returns.Method1.onCall = map[int]struct {
+			r0 parse.S1
+			r1 error
+		}{}
+	}
+
+	mock.returns.Method1.onCall[i] = struct {
+		r0 parse.S1
+		r1 error
+	}{r0, r1}
+}
+
+// queuedMethod1 returns func returning results queued for the call of Method1.
+// Returns nil if no results are set for the call and none are queued by Method1Returns.
+func (mock *MockI3) queuedMethod1(call int) func(a int, b string) (parse.S1, error) {
+	returns := &mock.returns.Method1
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method Method1 are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func(a int, b string) (parse.S1, error) {
+		return res.r0, res.r1
+	}
+}
+
+func (mock *MockI3) Method1(a int, b string) (parse.S1, error) {
+	fn := mock.Method1Func
+	if fn == nil {
+		fn = mock.queuedMethod1(len(mock.Calls.Method1))
+	}
+	if fn == nil {
+		if mock.impl == nil {
+			panic("nil method Method1 is called!")
+		}
+
+		fn = mock.impl.Method1
+	}
+
+	r0, r1 := fn(a, b)
+
+	callInfo := struct {
+		a  int
+		b  string
+		r0 parse.S1
+		r1 error
+	}{a, b, r0, r1}
+
+	mock.Calls.Method1 = append(mock.Calls.Method1, callInfo)
+
+	return r0, r1
+}
+
+func (mock *MockI3) Method2(s *parse.S4[string]) {
+	fn := mock.Method2Func
+	if fn == nil {
+		if mock.impl == nil {
+			panic("nil method Method2 is called!")
+		}
+
+		fn = mock.impl.Method2
+	}
+
+	fn(s)
+
+	callInfo := struct {
+		s *parse.S4[string]
+	}{s}
+
+	mock.Calls.Method2 = append(mock.Calls.Method2, callInfo)
+}
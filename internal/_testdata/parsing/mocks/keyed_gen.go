@@ -0,0 +1,169 @@
+// Code generated by "genpls:mock"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package mocks
+
+// *MockKeyed implements Keyed.
+type MockKeyed[V any] struct {
+	GetFunc  func(key string) ([]V, error)
+	KeysFunc func() []string
+
+	Calls struct {
+		Get []struct {
+			key string
+		}
+		Keys []struct {
+		}
+	}
+
+	returns struct {
+		Get struct {
+			queue []struct {
+				r0 []V
+				r1 error
+			}
+			onCall map[int]struct {
+				r0 []V
+				r1 error
+			}
+			next int
+		}
+		Keys struct {
+			queue []struct {
+				r0 []string
+			}
+			onCall map[int]struct {
+				r0 []string
+			}
+			next int
+		}
+	}
+}
+
+// GetReturns queues results returned by the next calls of Get while GetFunc is nil.
+func (mock *MockKeyed[V]) GetReturns(r0 []V, r1 error) {
+	mock.returns.Get.queue = append(mock.returns.Get.queue, struct {
+		r0 []V
+		r1 error
+	}{r0, r1})
+}
+
+// GetReturnsOnCall sets results returned by the i-th (zero-based) call of Get while GetFunc is nil.
+func (mock *MockKeyed[V]) GetReturnsOnCall(i int, r0 []V, r1 error) {
+	if mock.returns.Get.onCall == nil {
+		mock.returns.Get.onCall = map[int]struct {
+			r0 []V
+			r1 error
+		}{}
+	}
+
+	mock.returns.Get.onCall[i] = struct {
+		r0 []V
+		r1 error
+	}{r0, r1}
+}
+
+// queuedGet returns func returning results queued for the call of Get.
+// Returns nil if no results are set for the call and none are queued by GetReturns.
+func (mock *MockKeyed[V]) queuedGet(call int) func(key string) ([]V, error) {
+	returns := &mock.returns.Get
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method Get are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func(key string) ([]V, error) {
+		return res.r0, res.r1
+	}
+}
+
+// Get returns the value stored by the key.
+func (mock *MockKeyed[V]) Get(key string) ([]V, error) {
+	fn := mock.GetFunc
+	if fn == nil {
+		fn = mock.queuedGet(len(mock.Calls.Get))
+	}
+	if fn == nil {
+		panic("nil method Get is called!")
+	}
+
+	callInfo := struct {
+		key string
+	}{key}
+
+	mock.Calls.Get = append(mock.Calls.Get, callInfo)
+
+	return fn(key)
+}
+
+// KeysReturns queues results returned by the next calls of Keys while KeysFunc is nil.
+func (mock *MockKeyed[V]) KeysReturns(r0 []string) {
+	mock.returns.Keys.queue = append(mock.returns.Keys.queue, struct {
+		r0 []string
+	}{r0})
+}
+
+// KeysReturnsOnCall sets results returned by the i-th (zero-based) call of Keys while KeysFunc is nil.
+func (mock *MockKeyed[V]) KeysReturnsOnCall(i int, r0 []string) {
+	if mock.returns.Keys.onCall == nil {
+		mock.returns.Keys.onCall = map[int]struct {
+			r0 []string
+		}{}
+	}
+
+	mock.returns.Keys.onCall[i] = struct {
+		r0 []string
+	}{r0}
+}
+
+// queuedKeys returns func returning results queued for the call of Keys.
+// Returns nil if no results are set for the call and none are queued by KeysReturns.
+func (mock *MockKeyed[V]) queuedKeys(call int) func() []string {
+	returns := &mock.returns.Keys
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method Keys are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func() []string {
+		return res.r0
+	}
+}
+
+// Keys returns the stored keys.
+func (mock *MockKeyed[V]) Keys() []string {
+	fn := mock.KeysFunc
+	if fn == nil {
+		fn = mock.queuedKeys(len(mock.Calls.Keys))
+	}
+	if fn == nil {
+		panic("nil method Keys is called!")
+	}
+
+	callInfo := struct {
+	}{}
+
+	mock.Calls.Keys = append(mock.Calls.Keys, callInfo)
+
+	return fn()
+}
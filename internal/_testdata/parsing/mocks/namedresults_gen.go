@@ -0,0 +1,382 @@
+// Code generated by "genpls:mock"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package mocks
+
+import (
+	"cmp"
+	"context"
+	"parse"
+	"slices"
+	"time"
+)
+
+// *MockNamedResults implements NamedResults.
+type MockNamedResults struct {
+	AFunc func() (r0 int)
+	BFunc func(ctx context.Context, id string) (r0 string, r1 error)
+	CFunc func() (r0 int, _ error)
+
+	Calls struct {
+		A []struct {
+			r0_1  int
+			Seq   uint64
+			Start time.Time
+			End   time.Time
+		}
+		B []struct {
+			ctx   context.Context
+			id    string
+			r0_1  string
+			r1_1  error
+			Seq   uint64
+			Start time.Time
+			End   time.Time
+		}
+		C []struct {
+			r0_1  int
+			r1    error
+			Seq   uint64
+			Start time.Time
+			End   time.Time
+		}
+	}
+
+	impl parse.NamedResults
+
+	seq uint64
+
+	returns struct {
+		A struct {
+			queue []struct {
+				r0_1 int
+			}
+			onCall map[int]struct {
+				r0_1 int
+			}
+			next int
+		}
+		B struct {
+			queue []struct {
+				r0_1 string
+				r1_1 error
+			}
+			onCall map[int]struct {
+				r0_1 string
+				r1_1 error
+			}
+			next int
+		}
+		C struct {
+			queue []struct {
+				r0_1 int
+				r1   error
+			}
+			onCall map[int]struct {
+				r0_1 int
+				r1   error
+			}
+			next int
+		}
+	}
+}
+
+// NewMockNamedResults returns a new *MockNamedResults which calls impl for the methods with nil funcs.
+func NewMockNamedResults(impl parse.NamedResults) *MockNamedResults {
+	return &MockNamedResults{impl: impl}
+}
+
+// MockNamedResultsCall is a record of the MockNamedResults's method call.
+type MockNamedResultsCall struct {
+	Method  string
+	Seq     uint64
+	Start   time.Time
+	End     time.Time
+	Args    []any
+	Results []any
+}
+
+// CallLog returns calls of all methods ordered by the sequence number.
+func (mock *MockNamedResults) CallLog() []MockNamedResultsCall {
+	var log []MockNamedResultsCall
+
+	for _, c := range mock.Calls.A {
+		log = append(log, MockNamedResultsCall{
+			Method:  "A",
+			Seq:     c.Seq,
+			Start:   c.Start,
+			End:     c.End,
+			Args:    []any{},
+			Results: []any{c.r0_1},
+		})
+	}
+
+	for _, c := range mock.Calls.B {
+		log = append(log, MockNamedResultsCall{
+			Method:  "B",
+			Seq:     c.Seq,
+			Start:   c.Start,
+			End:     c.End,
+			Args:    []any{c.ctx, c.id},
+			Results: []any{c.r0_1, c.r1_1},
+		})
+	}
+
+	for _, c := range mock.Calls.C {
+		log = append(log, MockNamedResultsCall{
+			Method:  "C",
+			Seq:     c.Seq,
+			Start:   c.Start,
+			End:     c.End,
+			Args:    []any{},
+			Results: []any{c.r0_1, c.r1},
+		})
+	}
+
+	slices.SortFunc(log, func(a, b MockNamedResultsCall) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+
+	return log
+}
+
+// AReturns queues results returned by the next calls of A while AFunc is nil.
+func (mock *MockNamedResults) AReturns(r0_1 int) {
+	mock.returns.A.queue = append(mock.returns.A.queue, struct {
+		r0_1 int
+	}{r0_1})
+}
+
+// AReturnsOnCall sets results returned by the i-th (zero-based) call of A while AFunc is nil.
+func (mock *MockNamedResults) AReturnsOnCall(i int, r0_1 int) {
+	if mock.returns.A.onCall == nil {
+		mock.returns.A.onCall = map[int]struct {
+			r0_1 int
+		}{}
+	}
+
+	mock.returns.A.onCall[i] = struct {
+		r0_1 int
+	}{r0_1}
+}
+
+// queuedA returns func returning results queued for the call of A.
+// Returns nil if no results are set for the call and none are queued by AReturns.
+func (mock *MockNamedResults) queuedA(call int) func() (r0 int) {
+	returns := &mock.returns.A
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method A are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func() (r0 int) {
+		return res.r0_1
+	}
+}
+
+func (mock *MockNamedResults) A() (r0 int) {
+	fn := mock.AFunc
+	if fn == nil {
+		fn = mock.queuedA(len(mock.Calls.A))
+	}
+	if fn == nil {
+		if mock.impl == nil {
+			panic("nil method A is called!")
+		}
+
+		fn = mock.impl.A
+	}
+
+	mock.seq++
+	seq := mock.seq
+	start := time.Now()
+
+	r0_1 := fn()
+
+	end := time.Now()
+
+	callInfo := struct {
+		r0_1  int
+		Seq   uint64
+		Start time.Time
+		End   time.Time
+	}{r0_1, seq, start, end}
+
+	mock.Calls.A = append(mock.Calls.A, callInfo)
+
+	return r0_1
+}
+
+// BReturns queues results returned by the next calls of B while BFunc is nil.
+func (mock *MockNamedResults) BReturns(r0_1 string, r1_1 error) {
+	mock.returns.B.queue = append(mock.returns.B.queue, struct {
+		r0_1 string
+		r1_1 error
+	}{r0_1, r1_1})
+}
+
+// BReturnsOnCall sets results returned by the i-th (zero-based) call of B while BFunc is nil.
+func (mock *MockNamedResults) BReturnsOnCall(i int, r0_1 string, r1_1 error) {
+	if mock.returns.B.onCall == nil {
+		mock.returns.B.onCall = map[int]struct {
+			r0_1 string
+			r1_1 error
+		}{}
+	}
+
+	mock.returns.B.onCall[i] = struct {
+		r0_1 string
+		r1_1 error
+	}{r0_1, r1_1}
+}
+
+// queuedB returns func returning results queued for the call of B.
+// Returns nil if no results are set for the call and none are queued by BReturns.
+func (mock *MockNamedResults) queuedB(call int) func(ctx context.Context, id string) (r0 string, r1 error) {
+	returns := &mock.returns.B
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method B are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func(ctx context.Context, id string) (r0 string, r1 error) {
+		return res.r0_1, res.r1_1
+	}
+}
+
+func (mock *MockNamedResults) B(ctx context.Context, id string) (r0 string, r1 error) {
+	fn := mock.BFunc
+	if fn == nil {
+		fn = mock.queuedB(len(mock.Calls.B))
+	}
+	if fn == nil {
+		if mock.impl == nil {
+			panic("nil method B is called!")
+		}
+
+		fn = mock.impl.B
+	}
+
+	mock.seq++
+	seq := mock.seq
+	start := time.Now()
+
+	r0_1, r1_1 := fn(ctx, id)
+
+	end := time.Now()
+
+	callInfo := struct {
+		ctx   context.Context
+		id    string
+		r0_1  string
+		r1_1  error
+		Seq   uint64
+		Start time.Time
+		End   time.Time
+	}{ctx, id, r0_1, r1_1, seq, start, end}
+
+	mock.Calls.B = append(mock.Calls.B, callInfo)
+
+	return r0_1, r1_1
+}
+
+// CReturns queues results returned by the next calls of C while CFunc is nil.
+func (mock *MockNamedResults) CReturns(r0_1 int, r1 error) {
+	mock.returns.C.queue = append(mock.returns.C.queue, struct {
+		r0_1 int
+		r1   error
+	}{r0_1, r1})
+}
+
+// CReturnsOnCall sets results returned by the i-th (zero-based) call of C while CFunc is nil.
+func (mock *MockNamedResults) CReturnsOnCall(i int, r0_1 int, r1 error) {
+	if mock.returns.C.onCall == nil {
+		mock.returns.C.onCall = map[int]struct {
+			r0_1 int
+			r1   error
+		}{}
+	}
+
+	mock.returns.C.onCall[i] = struct {
+		r0_1 int
+		r1   error
+	}{r0_1, r1}
+}
+
+// queuedC returns func returning results queued for the call of C.
+// Returns nil if no results are set for the call and none are queued by CReturns.
+func (mock *MockNamedResults) queuedC(call int) func() (r0 int, _ error) {
+	returns := &mock.returns.C
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method C are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func() (r0 int, _ error) {
+		return res.r0_1, res.r1
+	}
+}
+
+func (mock *MockNamedResults) C() (r0 int, _ error) {
+	fn := mock.CFunc
+	if fn == nil {
+		fn = mock.queuedC(len(mock.Calls.C))
+	}
+	if fn == nil {
+		if mock.impl == nil {
+			panic("nil method C is called!")
+		}
+
+		fn = mock.impl.C
+	}
+
+	mock.seq++
+	seq := mock.seq
+	start := time.Now()
+
+	r0_1, r1 := fn()
+
+	end := time.Now()
+
+	callInfo := struct {
+		r0_1  int
+		r1    error
+		Seq   uint64
+		Start time.Time
+		End   time.Time
+	}{r0_1, r1, seq, start, end}
+
+	mock.Calls.C = append(mock.Calls.C, callInfo)
+
+	return r0_1, r1
+}
@@ -0,0 +1,99 @@
+// Code generated by "genpls:mock"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package mocks
+
+import (
+	"parse"
+)
+
+// *MockS1Getter implements S1Getter.
+type MockS1Getter struct {
+	GetFunc func(key int) (parse.S1, error)
+
+	Calls struct {
+		Get []struct {
+			key int
+		}
+	}
+
+	returns struct {
+		Get struct {
+			queue []struct {
+				r0 parse.S1
+				r1 error
+			}
+			onCall map[int]struct {
+				r0 parse.S1
+				r1 error
+			}
+			next int
+		}
+	}
+}
+
+// GetReturns queues results returned by the next calls of Get while GetFunc is nil.
+func (mock *MockS1Getter) GetReturns(r0 parse.S1, r1 error) {
+	mock.returns.Get.queue = append(mock.returns.Get.queue, struct {
+		r0 parse.S1
+		r1 error
+	}{r0, r1})
+}
+
+// GetReturnsOnCall sets results returned by the i-th (zero-based) call of Get while GetFunc is nil.
+func (mock *MockS1Getter) GetReturnsOnCall(i int, r0 parse.S1, r1 error) {
+	if mock.returns.Get.onCall == nil {
+		mock.returns.Get.onCall = map[int]struct {
+			r0 parse.S1
+			r1 error
+		}{}
+	}
+
+	mock.returns.Get.onCall[i] = struct {
+		r0 parse.S1
+		r1 error
+	}{r0, r1}
+}
+
+// queuedGet returns func returning results queued for the call of Get.
+// Returns nil if no results are set for the call and none are queued by GetReturns.
+func (mock *MockS1Getter) queuedGet(call int) func(key int) (parse.S1, error) {
+	returns := &mock.returns.Get
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method Get are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func(key int) (parse.S1, error) {
+		return res.r0, res.r1
+	}
+}
+
+// Get returns the value stored by the key.
+func (mock *MockS1Getter) Get(key int) (parse.S1, error) {
+	fn := mock.GetFunc
+	if fn == nil {
+		fn = mock.queuedGet(len(mock.Calls.Get))
+	}
+	if fn == nil {
+		panic("nil method Get is called!")
+	}
+
+	callInfo := struct {
+		key int
+	}{key}
+
+	mock.Calls.Get = append(mock.Calls.Get, callInfo)
+
+	return fn(key)
+}
@@ -0,0 +1,158 @@
+// Code generated by "genpls:mock"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package mocks
+
+import (
+	"cmp"
+	"context"
+	"go/types"
+	"slices"
+	time_1 "time"
+)
+
+// *MockShadowed implements Shadowed.
+type MockShadowed struct {
+	ParseFunc func(ctx context.Context, fmt string, time *types.Package) (errors []error, err error)
+
+	Calls struct {
+		Parse []struct {
+			ctx   context.Context
+			fmt   string
+			time  *types.Package
+			r0    []error
+			r1    error
+			Seq   uint64
+			Start time_1.Time
+			End   time_1.Time
+		}
+	}
+
+	seq uint64
+
+	returns struct {
+		Parse struct {
+			queue []struct {
+				r0 []error
+				r1 error
+			}
+			onCall map[int]struct {
+				r0 []error
+				r1 error
+			}
+			next int
+		}
+	}
+}
+
+// MockShadowedCall is a record of the MockShadowed's method call.
+type MockShadowedCall struct {
+	Method  string
+	Seq     uint64
+	Start   time_1.Time
+	End     time_1.Time
+	Args    []any
+	Results []any
+}
+
+// CallLog returns calls of all methods ordered by the sequence number.
+func (mock *MockShadowed) CallLog() []MockShadowedCall {
+	var log []MockShadowedCall
+
+	for _, c := range mock.Calls.Parse {
+		log = append(log, MockShadowedCall{
+			Method:  "Parse",
+			Seq:     c.Seq,
+			Start:   c.Start,
+			End:     c.End,
+			Args:    []any{c.ctx, c.fmt, c.time},
+			Results: []any{c.r0, c.r1},
+		})
+	}
+
+	slices.SortFunc(log, func(a, b MockShadowedCall) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+
+	return log
+}
+
+// ParseReturns queues results returned by the next calls of Parse while ParseFunc is nil.
+func (mock *MockShadowed) ParseReturns(r0 []error, r1 error) {
+	mock.returns.Parse.queue = append(mock.returns.Parse.queue, struct {
+		r0 []error
+		r1 error
+	}{r0, r1})
+}
+
+// ParseReturnsOnCall sets results returned by the i-th (zero-based) call of Parse while ParseFunc is nil.
+func (mock *MockShadowed) ParseReturnsOnCall(i int, r0 []error, r1 error) {
+	if mock.returns.Parse.onCall == nil {
+		mock.returns.Parse.onCall = map[int]struct {
+			r0 []error
+			r1 error
+		}{}
+	}
+
+	mock.returns.Parse.onCall[i] = struct {
+		r0 []error
+		r1 error
+	}{r0, r1}
+}
+
+// queuedParse returns func returning results queued for the call of Parse.
+// Returns nil if no results are set for the call and none are queued by ParseReturns.
+func (mock *MockShadowed) queuedParse(call int) func(ctx context.Context, fmt string, time *types.Package) (errors []error, err error) {
+	returns := &mock.returns.Parse
+
+	res, ok := returns.onCall[call]
+	if !ok {
+		if len(returns.queue) == 0 {
+			return nil
+		}
+
+		if returns.next >= len(returns.queue) {
+			panic("queued results of method Parse are exhausted!")
+		}
+
+		res = returns.queue[returns.next]
+		returns.next++
+	}
+
+	return func(ctx context.Context, fmt string, time *types.Package) (errors []error, err error) {
+		return res.r0, res.r1
+	}
+}
+
+func (mock *MockShadowed) Parse(ctx context.Context, fmt string, time *types.Package) (errors []error, err error) {
+	fn := mock.ParseFunc
+	if fn == nil {
+		fn = mock.queuedParse(len(mock.Calls.Parse))
+	}
+	if fn == nil {
+		panic("nil method Parse is called!")
+	}
+
+	mock.seq++
+	seq := mock.seq
+	start := time_1.Now()
+
+	r0, r1 := fn(ctx, fmt, time)
+
+	end := time_1.Now()
+
+	callInfo := struct {
+		ctx   context.Context
+		fmt   string
+		time  *types.Package
+		r0    []error
+		r1    error
+		Seq   uint64
+		Start time_1.Time
+		End   time_1.Time
+	}{ctx, fmt, time, r0, r1, seq, start, end}
+
+	mock.Calls.Parse = append(mock.Calls.Parse, callInfo)
+
+	return r0, r1
+}
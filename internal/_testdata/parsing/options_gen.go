@@ -0,0 +1,109 @@
+// Code generated by "genpls:options"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	io_1 "io"
+	"time"
+)
+
+// Option configures ServerConfig created by NewServerConfig.
+type Option func(*ServerConfig)
+
+// NewServerConfig returns a new *ServerConfig configured by the options.
+// The options are applied over the default values.
+// It returns the error of the Validate method of the configured ServerConfig.
+func NewServerConfig(opts ...Option) (*ServerConfig, error) {
+	c := &ServerConfig{Addr: ":8080", Timeout: 90 * time.Second, Ratio: 0.75, Limit: -0x10, Debug: true, retries: 3}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// WithAddr sets Addr.
+//
+// Addr is the listen address.
+func WithAddr(v string) Option {
+	return func(c *ServerConfig) {
+		c.Addr = v
+	}
+}
+
+// WithTimeout sets Timeout.
+func WithTimeout(v time.Duration) Option {
+	return func(c *ServerConfig) {
+		c.Timeout = v
+	}
+}
+
+// WithRatio sets Ratio.
+func WithRatio(v float64) Option {
+	return func(c *ServerConfig) {
+		c.Ratio = v
+	}
+}
+
+// WithLimit sets Limit.
+func WithLimit(v int32) Option {
+	return func(c *ServerConfig) {
+		c.Limit = v
+	}
+}
+
+// WithDebug sets Debug.
+func WithDebug(v bool) Option {
+	return func(c *ServerConfig) {
+		c.Debug = v
+	}
+}
+
+// WithLogger sets Logger.
+func WithLogger(v io_1.Writer) Option {
+	return func(c *ServerConfig) {
+		c.Logger = v
+	}
+}
+
+// WithRetries sets retries.
+func WithRetries(v uint8) Option {
+	return func(c *ServerConfig) {
+		c.retries = v
+	}
+}
+
+// PoolOpt configures pool created by newPool.
+type PoolOpt[T any] func(*pool[T])
+
+// newPool returns a new *pool configured by the options.
+// The options are applied over the default values.
+func newPool[T any](opts ...PoolOpt[T]) *pool[T] {
+	c := &pool[T]{size: 8}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// PoolSize sets size.
+func PoolSize[T any](v int) PoolOpt[T] {
+	return func(c *pool[T]) {
+		c.size = v
+	}
+}
+
+// PoolNew sets New.
+func PoolNew[T any](v func() T) PoolOpt[T] {
+	return func(c *pool[T]) {
+		c.New = v
+	}
+}
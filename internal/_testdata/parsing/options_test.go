@@ -0,0 +1,72 @@
+package parse
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestNewServerConfig_defaults(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewServerConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Addr != ":8080" || c.Timeout != 90*time.Second || c.Ratio != 0.75 || c.Limit != -16 || !c.Debug ||
+		c.Logger != nil || c.retries != 3 {
+		t.Fatalf("got %+v, want the default values", c)
+	}
+}
+
+func TestNewServerConfig_options(t *testing.T) {
+	t.Parallel()
+
+	var logger bytes.Buffer
+
+	c, err := NewServerConfig(
+		WithAddr(":9090"),
+		WithTimeout(time.Second),
+		WithRatio(0.5),
+		WithLimit(10),
+		WithDebug(false),
+		WithLogger(&logger),
+		WithRetries(5),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Addr != ":9090" || c.Timeout != time.Second || c.Ratio != 0.5 || c.Limit != 10 || c.Debug ||
+		c.Logger != &logger || c.retries != 5 {
+		t.Fatalf("got %+v, want the values of the options", c)
+	}
+}
+
+func TestNewServerConfig_validate(t *testing.T) {
+	t.Parallel()
+
+	// The options override the defaults before Validate is called.
+	c, err := NewServerConfig(WithAddr(""))
+	if err == nil || err.Error() != "empty address" {
+		t.Fatalf("got error %v, want the error of Validate", err)
+	}
+
+	if c != nil {
+		t.Fatalf("got %+v, want nil config on the invalid options", c)
+	}
+}
+
+func TestNewPool(t *testing.T) {
+	t.Parallel()
+
+	if p := newPool[int](); p.size != 8 || p.New != nil {
+		t.Fatalf("got %+v, want the default values", p)
+	}
+
+	p := newPool(PoolSize[string](2), PoolNew(func() string { return "new" }))
+	if p.size != 2 || p.New() != "new" {
+		t.Fatalf("got size %d, want the values of the options", p.size)
+	}
+}
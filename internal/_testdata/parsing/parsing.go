@@ -1,8 +1,11 @@
 package parse
 
 import (
+	"context"
+	"errors"
 	"go/types"
 	io_1 "io"
+	"time"
 
 	types_1 "parse/types"
 	types_2 "parse/types"
@@ -56,6 +59,8 @@ func (_ S3) method5() {}
 func (_ *S3) method6() {}
 
 //genpls:test S4
+//genpls:builder
+//genpls:builder -dir=builders
 type S4[T any] struct {
 	// S4Field doc
 	S4Field T
@@ -76,7 +81,9 @@ type I1 interface {
 
 //genpls:stub
 //genpls:proxy
-//genpls:mock
+//genpls:mock -history
+//genpls:recover
+//genpls:retry -sorted
 type I2[T any, U comparable, Q io_1.Reader] interface {
 	IMethod1()
 	imethod2(t T) (u U)
@@ -86,3 +93,246 @@ type I2[T any, U comparable, Q io_1.Reader] interface {
 //genpls:proxy
 //genpls:mock
 type AliasIface = I1
+
+//genpls:mock -spy
+//genpls:mock -spy -history -dir=. -name=i3_spy
+//genpls:stub -mode=zero
+//genpls:proxy -interceptor
+//genpls:recover -log
+//genpls:breaker
+//genpls:metrics
+//genpls:trace -background
+//genpls:cache -methods=Method1
+type I3 interface {
+	Method1(a int, b string) (S1, error)
+	Method2(s *S4[string])
+}
+
+type Item struct {
+	ID   string
+	Name string
+}
+
+//genpls:fake -get=GetItem -put=PutItem -list=ListItems -delete=DeleteItem
+//genpls:stub -mode=error
+//genpls:proxy -logger=slog -timing
+//genpls:retry -skip=Close -methods=PutItem:5 -backoff=50ms
+//genpls:breaker -skip=Close -rate=10 -burst=5
+//genpls:metrics -expvar
+//genpls:trace
+//genpls:cache -methods=GetItem:5m,ListItems -size=128
+type Repo interface {
+	GetItem(ctx context.Context, id string) (Item, error)
+	PutItem(ctx context.Context, item Item) error
+	ListItems(ctx context.Context) ([]Item, error)
+	DeleteItem(ctx context.Context, id string) error
+	Count(ctx context.Context) (int, error)
+	Close() error
+}
+
+//genpls:fake
+type Store[K comparable, V any] interface {
+	Get(ctx context.Context, key K) (V, error)
+	GetByName(ctx context.Context, name string) (V, error)
+	Put(ctx context.Context, v V) error
+	Delete(ctx context.Context, key K) error
+}
+
+type Secret string
+
+func (Secret) Redacted() {}
+
+//genpls:proxy -redact=password -threshold=100ms
+type Auth interface {
+	Login(ctx context.Context, user string, password string) (Secret, error)
+	// Refresh exchanges the token.
+	//
+	//genpls:redact token
+	Refresh(ctx context.Context, token string) (Secret, error)
+}
+
+// SlogAuth logs calls of Auth by slog.
+//
+//genpls:proxy -logger=slog -redact=password
+type SlogAuth = Auth
+
+// SlowAuth logs slow calls of Auth by slog.
+//
+//genpls:proxy -logger=slog -threshold=50ms
+type SlowAuth = Auth
+
+//genpls:stub -mode=error
+//genpls:proxy -logger=slog -timing
+//genpls:mock -history
+type Shadowed interface {
+	Parse(ctx context.Context, fmt string, time *types.Package) (errors []error, err error)
+}
+
+type Getter[K comparable, V any] interface {
+	// Get returns the value stored by the key.
+	Get(key K) (V, error)
+}
+
+//genpls:stub
+//genpls:proxy
+//genpls:mock
+type Embedded interface {
+	Getter[string, S1]
+	io_1.ReadCloser
+
+	// Name returns the name.
+	Name() string
+}
+
+//genpls:stub -mode=zero
+//genpls:mock
+//genpls:cache -ttl=30s -methods=Get
+type Keyed[V any] interface {
+	Getter[string, []V]
+	// Keys returns the stored keys.
+	Keys() []string
+}
+
+//genpls:stub -mode=error
+//genpls:mock
+type S1Getter = Getter[int, S1]
+
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+//genpls:stub
+//genpls:proxy
+//genpls:mock -history
+//genpls:recover
+type Calc[T Number, S ~[]T, K interface {
+	comparable
+	String() string
+}] interface {
+	Sum(values S) T
+	Lookup(key K) (T, error)
+}
+
+// Config is built by the builders.
+//
+//genpls:builder -required=ID
+//genpls:builder -dir=builders -name=config
+type Config struct {
+	// ID identifies the config.
+	ID   string
+	Name string `genpls:"required"`
+	Tags []string
+	io_1.Reader
+
+	retries int
+	secret  string `genpls:"-"`
+}
+
+// ServerConfig is configured by the options.
+//
+//genpls:options
+type ServerConfig struct {
+	// Addr is the listen address.
+	Addr    string        `default:":8080"`
+	Timeout time.Duration `default:"1m30s"`
+	Ratio   float64       `default:"0.75"`
+	Limit   int32         `default:"-0x10"`
+	Debug   bool          `default:"true"`
+	Logger  io_1.Writer
+	Hook    func() error `genpls:"-"`
+
+	retries uint8 `default:"3"`
+}
+
+// Validate is called by NewServerConfig.
+func (c *ServerConfig) Validate() error {
+	if c.Addr == "" {
+		return errors.New("empty address")
+	}
+
+	return nil
+}
+
+//genpls:options -option=PoolOpt -prefix=Pool
+type pool[T any] struct {
+	size int `default:"8"`
+	New  func() T
+}
+
+// Message is shared by the accessors.
+//
+//genpls:accessors
+type Message struct {
+	// ID identifies the message.
+	ID      int64 `genpls:"readonly"`
+	Name    string
+	Payload []byte `genpls:"set"`
+	Header  map[string]string
+	Meta    S1
+	Parent  *Message
+	io_1.Reader
+
+	// version is accessed by the unexported accessors.
+	version  uint32 `genpls:"set"`
+	internal bool   `genpls:"-"`
+}
+
+// GetName returns the name of the message.
+func (m Message) GetName() string {
+	return m.Name
+}
+
+//genpls:accessors -set
+type Node[T any] struct {
+	Value T
+	next  *Node[T]
+}
+
+// SetValue replaces the node's value.
+func (n *Node[T]) SetValue(value T) {
+	n.Value = value
+}
+
+// Clash has the parameter named like the generated results.
+//
+//genpls:recover
+//genpls:retry
+//genpls:breaker
+//genpls:metrics
+//genpls:trace
+//genpls:cache -methods=A,B
+//genpls:mock -history
+//genpls:proxy -interceptor
+//genpls:stub -mode=zero
+type Clash interface {
+	A(ctx context.Context, r0 int) (int, error)
+	B(res string, returns int, ok bool, call int) (string, error)
+}
+
+// Logged has the parameters named like the slog proxy locals.
+//
+//genpls:proxy -logger=slog -timing
+type Logged interface {
+	C(err error, logger string, interceptor int) error
+}
+
+// NamedResults has the results named like the generated results.
+//
+//genpls:stub -mode=zero
+//genpls:recover
+//genpls:retry
+//genpls:metrics
+//genpls:trace
+//genpls:proxy
+//genpls:mock -spy -history
+type NamedResults interface {
+	A() (r0 int)
+	B(ctx context.Context, id string) (r0 string, r1 error)
+	C() (r0 int, _ error)
+}
+
+//genpls:proxy -logger=slog -timing
+type SlogNamedResults = NamedResults
+
+//genpls:proxy -interceptor
+type InterceptedNamedResults = NamedResults
@@ -4,24 +4,61 @@
 package parse
 
 import (
-	"errors"
+	"context"
+	errors_1 "errors"
+	fmt_1 "fmt"
 	"go/types"
 	io_1 "io"
+	"log/slog"
 	types_2 "parse/types"
+	time_1 "time"
 )
 
+// *ProxyI1 implements I1.
+type ProxyI1 struct {
+	v      I1
+	logger interface{ Log(string, ...any) }
+}
+
+func NewProxyI1(v I1, logger interface{ Log(string, ...any) }) (*ProxyI1, error) {
+	if v == nil {
+		return nil, errors_1.New("v is nil")
+	}
+	if logger == nil {
+		return nil, errors_1.New("logger is nil")
+	}
+	return &ProxyI1{
+		v:      v,
+		logger: logger,
+	}, nil
+}
+
+// IMethod1 doc
+func (p *ProxyI1) IMethod1() {
+	p.logger.Log("Calling IMethod1", "arguments")
+	p.v.IMethod1()
+	p.logger.Log("Calling IMethod1", "results")
+}
+
+// imethod2 doc
+func (p *ProxyI1) imethod2() {
+	p.logger.Log("Calling imethod2", "arguments")
+	p.v.imethod2()
+	p.logger.Log("Calling imethod2", "results")
+}
+
 // *ProxyI2 implements I2.
 type ProxyI2[T any, U comparable, Q io_1.Reader] struct {
 	v      I2[T, U, Q]
-	logger interface{Log(string, ...any)}
+	logger interface{ Log(string, ...any) }
 }
 
-func NewProxyI2[T any, U comparable, Q io_1.Reader](v I2[T, U, Q], logger interface{Log(string, ...any)}) (*ProxyI2[T, U, Q], error) {
+func NewProxyI2[T any, U comparable, Q io_1.Reader](v I2[T, U, Q], logger interface{ Log(string, ...any) }) (*ProxyI2[T, U, Q], error) {
 	if v == nil {
-		return nil, errors.New("v is nil")
+		return nil, errors_1.New("v is nil")
 	}
 	if logger == nil {
-		return nil, errors.New("logger is nil")
+		return nil, errors_1.New("logger is nil")
 	}
 	return &ProxyI2[T, U, Q]{
 		v:      v,
@@ -30,18 +67,11 @@ func NewProxyI2[T any, U comparable, Q io_1.Reader](v I2[T, U, Q], logger interf
 }
 
 func (p *ProxyI2[T, U, Q]) IMethod1() {
-	p.logger.Log("Calling IMethod1", "arguments", )
+	p.logger.Log("Calling IMethod1", "arguments")
 	p.v.IMethod1()
 	p.logger.Log("Calling IMethod1", "results")
 }
 
-func (p *ProxyI2[T, U, Q]) IMethod3(a int, b types_2.S1, c types_2.S2[string], d types_2.S2[*types.Package]) (types_2.S1, error) {
-	p.logger.Log("Calling IMethod3", "arguments", a, b, c, d)
-	r0, r1 := p.v.IMethod3(a, b, c, d)
-	p.logger.Log("Calling IMethod3", "results", r0, r1)
-	return r0, r1
-}
-
 func (p *ProxyI2[T, U, Q]) imethod2(t T) (u U) {
 	p.logger.Log("Calling imethod2", "arguments", t)
 	r0 := p.v.imethod2(t)
@@ -49,65 +79,728 @@ func (p *ProxyI2[T, U, Q]) imethod2(t T) (u U) {
 	return r0
 }
 
-// *ProxyI1 implements I1.
-type ProxyI1 struct {
-	v      I1
-	logger interface{Log(string, ...any)}
+func (p *ProxyI2[T, U, Q]) IMethod3(a int, b types_2.S1, c types_2.S2[string], d types_2.S2[*types.Package]) (types_2.S1, error) {
+	p.logger.Log("Calling IMethod3", "arguments", a, b, c, d)
+	r0, r1 := p.v.IMethod3(a, b, c, d)
+	p.logger.Log("Calling IMethod3", "results", r0, r1)
+	return r0, r1
+}
+
+// *ProxyAliasIface implements AliasIface.
+type ProxyAliasIface struct {
+	v      AliasIface
+	logger interface{ Log(string, ...any) }
 }
 
-func NewProxyI1(v I1, logger interface{Log(string, ...any)}) (*ProxyI1, error) {
+func NewProxyAliasIface(v AliasIface, logger interface{ Log(string, ...any) }) (*ProxyAliasIface, error) {
 	if v == nil {
-		return nil, errors.New("v is nil")
+		return nil, errors_1.New("v is nil")
 	}
 	if logger == nil {
-		return nil, errors.New("logger is nil")
+		return nil, errors_1.New("logger is nil")
 	}
-	return &ProxyI1{
+	return &ProxyAliasIface{
 		v:      v,
 		logger: logger,
 	}, nil
 }
 
-func (p *ProxyI1) IMethod1() {
-	p.logger.Log("Calling IMethod1", "arguments", )
+// IMethod1 doc
+func (p *ProxyAliasIface) IMethod1() {
+	p.logger.Log("Calling IMethod1", "arguments")
 	p.v.IMethod1()
 	p.logger.Log("Calling IMethod1", "results")
 }
 
-func (p *ProxyI1) imethod2() {
-	p.logger.Log("Calling imethod2", "arguments", )
+// imethod2 doc
+func (p *ProxyAliasIface) imethod2() {
+	p.logger.Log("Calling imethod2", "arguments")
 	p.v.imethod2()
 	p.logger.Log("Calling imethod2", "results")
 }
 
-// *ProxyAliasIface implements AliasIface.
-type ProxyAliasIface struct {
-	v      AliasIface
-	logger interface{Log(string, ...any)}
+// ProxyI3Interceptor intercepts calls of the *ProxyI3 methods.
+// The method's arguments are passed by args except the leading context.
+// The next func calls the wrapped method and returns its results.
+// The interceptor must return as many results of the same types as next does, otherwise the method panics.
+// Nil is accepted for the results of interface types.
+type ProxyI3Interceptor func(ctx context.Context, method string, args []any, next func() []any) []any
+
+// *ProxyI3 implements I3.
+type ProxyI3 struct {
+	v           I3
+	interceptor ProxyI3Interceptor
 }
 
-func NewProxyAliasIface(v AliasIface, logger interface{Log(string, ...any)}) (*ProxyAliasIface, error) {
+func NewProxyI3(v I3, interceptor ProxyI3Interceptor) (*ProxyI3, error) {
 	if v == nil {
-		return nil, errors.New("v is nil")
+		return nil, errors_1.New("v is nil")
+	}
+	if interceptor == nil {
+		return nil, errors_1.New("interceptor is nil")
+	}
+	return &ProxyI3{
+		v:           v,
+		interceptor: interceptor,
+	}, nil
+}
+
+func (p *ProxyI3) Method1(a int, b string) (S1, error) {
+	res := p.interceptor(context.Background(), "Method1", []any{a, b}, func() []any {
+		r0, r1 := p.v.Method1(a, b)
+		return []any{r0, r1}
+	})
+
+	if len(res) != 2 {
+		panic(fmt_1.Sprintf("interceptor of method Method1 returns %d results instead of 2", len(res)))
+	}
+
+	r0, ok := res[0].(S1)
+	if !ok {
+		panic(fmt_1.Sprintf("interceptor of method Method1 returns %T result 0 instead of %s", res[0], "S1"))
+	}
+	r1, ok := res[1].(error)
+	if !ok && res[1] != nil {
+		panic(fmt_1.Sprintf("interceptor of method Method1 returns %T result 1 instead of %s", res[1], "error"))
+	}
+
+	return r0, r1
+}
+
+func (p *ProxyI3) Method2(s *S4[string]) {
+	p.interceptor(context.Background(), "Method2", []any{s}, func() []any {
+		p.v.Method2(s)
+		return nil
+	})
+}
+
+// *ProxyRepo implements Repo.
+type ProxyRepo struct {
+	v      Repo
+	logger *slog.Logger
+}
+
+func NewProxyRepo(v Repo, logger *slog.Logger) (*ProxyRepo, error) {
+	if v == nil {
+		return nil, errors_1.New("v is nil")
 	}
 	if logger == nil {
-		return nil, errors.New("logger is nil")
+		return nil, errors_1.New("logger is nil")
 	}
-	return &ProxyAliasIface{
+	return &ProxyRepo{
 		v:      v,
 		logger: logger,
 	}, nil
 }
 
-func (p *ProxyAliasIface) IMethod1() {
-	p.logger.Log("Calling IMethod1", "arguments", )
-	p.v.IMethod1()
-	p.logger.Log("Calling IMethod1", "results")
+func (p *ProxyRepo) GetItem(ctx context.Context, id string) (Item, error) {
+	p.logger.LogAttrs(ctx, slog.LevelInfo, "Calling GetItem", slog.Any("id", id))
+	start := time_1.Now()
+	r0, err := p.v.GetItem(ctx, id)
+	elapsed := time_1.Since(start)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+	p.logger.LogAttrs(ctx, level, "Called GetItem", slog.Any("r0", r0), slog.Any("err", err), slog.Duration("elapsed", elapsed))
+	return r0, err
 }
 
-func (p *ProxyAliasIface) imethod2() {
-	p.logger.Log("Calling imethod2", "arguments", )
-	p.v.imethod2()
-	p.logger.Log("Calling imethod2", "results")
+func (p *ProxyRepo) PutItem(ctx context.Context, item Item) error {
+	p.logger.LogAttrs(ctx, slog.LevelInfo, "Calling PutItem", slog.Any("item", item))
+	start := time_1.Now()
+	err := p.v.PutItem(ctx, item)
+	elapsed := time_1.Since(start)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+	p.logger.LogAttrs(ctx, level, "Called PutItem", slog.Any("err", err), slog.Duration("elapsed", elapsed))
+	return err
+}
+
+func (p *ProxyRepo) ListItems(ctx context.Context) ([]Item, error) {
+	p.logger.LogAttrs(ctx, slog.LevelInfo, "Calling ListItems")
+	start := time_1.Now()
+	r0, err := p.v.ListItems(ctx)
+	elapsed := time_1.Since(start)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+	p.logger.LogAttrs(ctx, level, "Called ListItems", slog.Any("r0", r0), slog.Any("err", err), slog.Duration("elapsed", elapsed))
+	return r0, err
+}
+
+func (p *ProxyRepo) DeleteItem(ctx context.Context, id string) error {
+	p.logger.LogAttrs(ctx, slog.LevelInfo, "Calling DeleteItem", slog.Any("id", id))
+	start := time_1.Now()
+	err := p.v.DeleteItem(ctx, id)
+	elapsed := time_1.Since(start)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+	p.logger.LogAttrs(ctx, level, "Called DeleteItem", slog.Any("err", err), slog.Duration("elapsed", elapsed))
+	return err
+}
+
+func (p *ProxyRepo) Count(ctx context.Context) (int, error) {
+	p.logger.LogAttrs(ctx, slog.LevelInfo, "Calling Count")
+	start := time_1.Now()
+	r0, err := p.v.Count(ctx)
+	elapsed := time_1.Since(start)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+	p.logger.LogAttrs(ctx, level, "Called Count", slog.Any("r0", r0), slog.Any("err", err), slog.Duration("elapsed", elapsed))
+	return r0, err
+}
+
+func (p *ProxyRepo) Close() error {
+	p.logger.LogAttrs(context.Background(), slog.LevelInfo, "Calling Close")
+	start := time_1.Now()
+	err := p.v.Close()
+	elapsed := time_1.Since(start)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+	p.logger.LogAttrs(context.Background(), level, "Called Close", slog.Any("err", err), slog.Duration("elapsed", elapsed))
+	return err
+}
+
+// *ProxyAuth implements Auth.
+type ProxyAuth struct {
+	v      Auth
+	logger interface{ Log(string, ...any) }
+}
+
+func NewProxyAuth(v Auth, logger interface{ Log(string, ...any) }) (*ProxyAuth, error) {
+	if v == nil {
+		return nil, errors_1.New("v is nil")
+	}
+	if logger == nil {
+		return nil, errors_1.New("logger is nil")
+	}
+	return &ProxyAuth{
+		v:      v,
+		logger: logger,
+	}, nil
+}
+
+func (p *ProxyAuth) Login(ctx context.Context, user string, password string) (Secret, error) {
+	start := time_1.Now()
+	r0, r1 := p.v.Login(ctx, user, password)
+	elapsed := time_1.Since(start)
+	if elapsed >= 100*time_1.Millisecond {
+		p.logger.Log("Calling Login", "arguments", ctx, user, "[REDACTED]", "results", "[REDACTED]", r1, "elapsed", elapsed)
+	}
+	return r0, r1
+}
+
+// Refresh exchanges the token.
+func (p *ProxyAuth) Refresh(ctx context.Context, token string) (Secret, error) {
+	start := time_1.Now()
+	r0, r1 := p.v.Refresh(ctx, token)
+	elapsed := time_1.Since(start)
+	if elapsed >= 100*time_1.Millisecond {
+		p.logger.Log("Calling Refresh", "arguments", ctx, "[REDACTED]", "results", "[REDACTED]", r1, "elapsed", elapsed)
+	}
+	return r0, r1
+}
+
+// *ProxySlogAuth implements SlogAuth.
+type ProxySlogAuth struct {
+	v      SlogAuth
+	logger *slog.Logger
+}
+
+func NewProxySlogAuth(v SlogAuth, logger *slog.Logger) (*ProxySlogAuth, error) {
+	if v == nil {
+		return nil, errors_1.New("v is nil")
+	}
+	if logger == nil {
+		return nil, errors_1.New("logger is nil")
+	}
+	return &ProxySlogAuth{
+		v:      v,
+		logger: logger,
+	}, nil
+}
+
+func (p *ProxySlogAuth) Login(ctx context.Context, user string, password string) (Secret, error) {
+	p.logger.LogAttrs(ctx, slog.LevelInfo, "Calling Login", slog.Any("user", user), slog.String("password", "[REDACTED]"))
+	r0, err := p.v.Login(ctx, user, password)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+	p.logger.LogAttrs(ctx, level, "Called Login", slog.String("r0", "[REDACTED]"), slog.Any("err", err))
+	return r0, err
+}
+
+// Refresh exchanges the token.
+func (p *ProxySlogAuth) Refresh(ctx context.Context, token string) (Secret, error) {
+	p.logger.LogAttrs(ctx, slog.LevelInfo, "Calling Refresh", slog.String("token", "[REDACTED]"))
+	r0, err := p.v.Refresh(ctx, token)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+	p.logger.LogAttrs(ctx, level, "Called Refresh", slog.String("r0", "[REDACTED]"), slog.Any("err", err))
+	return r0, err
+}
+
+// *ProxySlowAuth implements SlowAuth.
+type ProxySlowAuth struct {
+	v      SlowAuth
+	logger *slog.Logger
 }
 
+func NewProxySlowAuth(v SlowAuth, logger *slog.Logger) (*ProxySlowAuth, error) {
+	if v == nil {
+		return nil, errors_1.New("v is nil")
+	}
+	if logger == nil {
+		return nil, errors_1.New("logger is nil")
+	}
+	return &ProxySlowAuth{
+		v:      v,
+		logger: logger,
+	}, nil
+}
+
+func (p *ProxySlowAuth) Login(ctx context.Context, user string, password string) (Secret, error) {
+	start := time_1.Now()
+	r0, err := p.v.Login(ctx, user, password)
+	elapsed := time_1.Since(start)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+	if elapsed >= 50*time_1.Millisecond {
+		p.logger.LogAttrs(ctx, level, "Called Login", slog.Any("user", user), slog.Any("password", password), slog.String("r0", "[REDACTED]"), slog.Any("err", err), slog.Duration("elapsed", elapsed))
+	}
+	return r0, err
+}
+
+// Refresh exchanges the token.
+func (p *ProxySlowAuth) Refresh(ctx context.Context, token string) (Secret, error) {
+	start := time_1.Now()
+	r0, err := p.v.Refresh(ctx, token)
+	elapsed := time_1.Since(start)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+	if elapsed >= 50*time_1.Millisecond {
+		p.logger.LogAttrs(ctx, level, "Called Refresh", slog.String("token", "[REDACTED]"), slog.String("r0", "[REDACTED]"), slog.Any("err", err), slog.Duration("elapsed", elapsed))
+	}
+	return r0, err
+}
+
+// *ProxyShadowed implements Shadowed.
+type ProxyShadowed struct {
+	v      Shadowed
+	logger *slog.Logger
+}
+
+func NewProxyShadowed(v Shadowed, logger *slog.Logger) (*ProxyShadowed, error) {
+	if v == nil {
+		return nil, errors_1.New("v is nil")
+	}
+	if logger == nil {
+		return nil, errors_1.New("logger is nil")
+	}
+	return &ProxyShadowed{
+		v:      v,
+		logger: logger,
+	}, nil
+}
+
+func (p *ProxyShadowed) Parse(ctx context.Context, fmt string, time *types.Package) (errors []error, err_1 error) {
+	p.logger.LogAttrs(ctx, slog.LevelInfo, "Calling Parse", slog.Any("fmt", fmt), slog.Any("time", time))
+	start := time_1.Now()
+	r0, err := p.v.Parse(ctx, fmt, time)
+	elapsed := time_1.Since(start)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+	p.logger.LogAttrs(ctx, level, "Called Parse", slog.Any("r0", r0), slog.Any("err", err), slog.Duration("elapsed", elapsed))
+	return r0, err
+}
+
+// *ProxyEmbedded implements Embedded.
+type ProxyEmbedded struct {
+	v      Embedded
+	logger interface{ Log(string, ...any) }
+}
+
+func NewProxyEmbedded(v Embedded, logger interface{ Log(string, ...any) }) (*ProxyEmbedded, error) {
+	if v == nil {
+		return nil, errors_1.New("v is nil")
+	}
+	if logger == nil {
+		return nil, errors_1.New("logger is nil")
+	}
+	return &ProxyEmbedded{
+		v:      v,
+		logger: logger,
+	}, nil
+}
+
+// Get returns the value stored by the key.
+func (p *ProxyEmbedded) Get(key string) (S1, error) {
+	p.logger.Log("Calling Get", "arguments", key)
+	r0, r1 := p.v.Get(key)
+	p.logger.Log("Calling Get", "results", r0, r1)
+	return r0, r1
+}
+
+func (p *ProxyEmbedded) Read(p_1 []byte) (n int, err_1 error) {
+	p.logger.Log("Calling Read", "arguments", p_1)
+	r0, r1 := p.v.Read(p_1)
+	p.logger.Log("Calling Read", "results", r0, r1)
+	return r0, r1
+}
+
+func (p *ProxyEmbedded) Close() error {
+	p.logger.Log("Calling Close", "arguments")
+	r0 := p.v.Close()
+	p.logger.Log("Calling Close", "results", r0)
+	return r0
+}
+
+// Name returns the name.
+func (p *ProxyEmbedded) Name() string {
+	p.logger.Log("Calling Name", "arguments")
+	r0 := p.v.Name()
+	p.logger.Log("Calling Name", "results", r0)
+	return r0
+}
+
+// *ProxyCalc implements Calc.
+type ProxyCalc[T Number, S ~[]T, K interface {
+	String() string
+	comparable
+}] struct {
+	v      Calc[T, S, K]
+	logger interface{ Log(string, ...any) }
+}
+
+func NewProxyCalc[T Number, S ~[]T, K interface {
+	String() string
+	comparable
+}](v Calc[T, S, K], logger interface{ Log(string, ...any) }) (*ProxyCalc[T, S, K], error) {
+	if v == nil {
+		return nil, errors_1.New("v is nil")
+	}
+	if logger == nil {
+		return nil, errors_1.New("logger is nil")
+	}
+	return &ProxyCalc[T, S, K]{
+		v:      v,
+		logger: logger,
+	}, nil
+}
+
+func (p *ProxyCalc[T, S, K]) Sum(values S) T {
+	p.logger.Log("Calling Sum", "arguments", values)
+	r0 := p.v.Sum(values)
+	p.logger.Log("Calling Sum", "results", r0)
+	return r0
+}
+
+func (p *ProxyCalc[T, S, K]) Lookup(key K) (T, error) {
+	p.logger.Log("Calling Lookup", "arguments", key)
+	r0, r1 := p.v.Lookup(key)
+	p.logger.Log("Calling Lookup", "results", r0, r1)
+	return r0, r1
+}
+
+// ProxyClashInterceptor intercepts calls of the *ProxyClash methods.
+// The method's arguments are passed by args except the leading context.
+// The next func calls the wrapped method and returns its results.
+// The interceptor must return as many results of the same types as next does, otherwise the method panics.
+// Nil is accepted for the results of interface types.
+type ProxyClashInterceptor func(ctx context.Context, method string, args []any, next func() []any) []any
+
+// *ProxyClash implements Clash.
+type ProxyClash struct {
+	v           Clash
+	interceptor ProxyClashInterceptor
+}
+
+func NewProxyClash(v Clash, interceptor ProxyClashInterceptor) (*ProxyClash, error) {
+	if v == nil {
+		return nil, errors_1.New("v is nil")
+	}
+	if interceptor == nil {
+		return nil, errors_1.New("interceptor is nil")
+	}
+	return &ProxyClash{
+		v:           v,
+		interceptor: interceptor,
+	}, nil
+}
+
+func (p *ProxyClash) A(ctx context.Context, r0 int) (int, error) {
+	res := p.interceptor(ctx, "A", []any{r0}, func() []any {
+		r0_1, r1 := p.v.A(ctx, r0)
+		return []any{r0_1, r1}
+	})
+
+	if len(res) != 2 {
+		panic(fmt_1.Sprintf("interceptor of method A returns %d results instead of 2", len(res)))
+	}
+
+	r0_1, ok := res[0].(int)
+	if !ok {
+		panic(fmt_1.Sprintf("interceptor of method A returns %T result 0 instead of %s", res[0], "int"))
+	}
+	r1, ok := res[1].(error)
+	if !ok && res[1] != nil {
+		panic(fmt_1.Sprintf("interceptor of method A returns %T result 1 instead of %s", res[1], "error"))
+	}
+
+	return r0_1, r1
+}
+
+func (p *ProxyClash) B(res_1 string, returns int, ok_1 bool, call int) (string, error) {
+	res := p.interceptor(context.Background(), "B", []any{res_1, returns, ok_1, call}, func() []any {
+		r0, r1 := p.v.B(res_1, returns, ok_1, call)
+		return []any{r0, r1}
+	})
+
+	if len(res) != 2 {
+		panic(fmt_1.Sprintf("interceptor of method B returns %d results instead of 2", len(res)))
+	}
+
+	r0, ok := res[0].(string)
+	if !ok {
+		panic(fmt_1.Sprintf("interceptor of method B returns %T result 0 instead of %s", res[0], "string"))
+	}
+	r1, ok := res[1].(error)
+	if !ok && res[1] != nil {
+		panic(fmt_1.Sprintf("interceptor of method B returns %T result 1 instead of %s", res[1], "error"))
+	}
+
+	return r0, r1
+}
+
+// *ProxyLogged implements Logged.
+type ProxyLogged struct {
+	v      Logged
+	logger *slog.Logger
+}
+
+func NewProxyLogged(v Logged, logger *slog.Logger) (*ProxyLogged, error) {
+	if v == nil {
+		return nil, errors_1.New("v is nil")
+	}
+	if logger == nil {
+		return nil, errors_1.New("logger is nil")
+	}
+	return &ProxyLogged{
+		v:      v,
+		logger: logger,
+	}, nil
+}
+
+func (p *ProxyLogged) C(err_1 error, logger_1 string, interceptor_1 int) error {
+	p.logger.LogAttrs(context.Background(), slog.LevelInfo, "Calling C", slog.Any("err", err_1), slog.Any("logger", logger_1), slog.Any("interceptor", interceptor_1))
+	start := time_1.Now()
+	err := p.v.C(err_1, logger_1, interceptor_1)
+	elapsed := time_1.Since(start)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+	p.logger.LogAttrs(context.Background(), level, "Called C", slog.Any("err", err), slog.Duration("elapsed", elapsed))
+	return err
+}
+
+// *ProxyNamedResults implements NamedResults.
+type ProxyNamedResults struct {
+	v      NamedResults
+	logger interface{ Log(string, ...any) }
+}
+
+func NewProxyNamedResults(v NamedResults, logger interface{ Log(string, ...any) }) (*ProxyNamedResults, error) {
+	if v == nil {
+		return nil, errors_1.New("v is nil")
+	}
+	if logger == nil {
+		return nil, errors_1.New("logger is nil")
+	}
+	return &ProxyNamedResults{
+		v:      v,
+		logger: logger,
+	}, nil
+}
+
+func (p *ProxyNamedResults) A() (r0 int) {
+	p.logger.Log("Calling A", "arguments")
+	r0_1 := p.v.A()
+	p.logger.Log("Calling A", "results", r0_1)
+	return r0_1
+}
+
+func (p *ProxyNamedResults) B(ctx context.Context, id string) (r0 string, r1 error) {
+	p.logger.Log("Calling B", "arguments", ctx, id)
+	r0_1, r1_1 := p.v.B(ctx, id)
+	p.logger.Log("Calling B", "results", r0_1, r1_1)
+	return r0_1, r1_1
+}
+
+func (p *ProxyNamedResults) C() (r0 int, _ error) {
+	p.logger.Log("Calling C", "arguments")
+	r0_1, r1 := p.v.C()
+	p.logger.Log("Calling C", "results", r0_1, r1)
+	return r0_1, r1
+}
+
+// *ProxySlogNamedResults implements SlogNamedResults.
+type ProxySlogNamedResults struct {
+	v      SlogNamedResults
+	logger *slog.Logger
+}
+
+func NewProxySlogNamedResults(v SlogNamedResults, logger *slog.Logger) (*ProxySlogNamedResults, error) {
+	if v == nil {
+		return nil, errors_1.New("v is nil")
+	}
+	if logger == nil {
+		return nil, errors_1.New("logger is nil")
+	}
+	return &ProxySlogNamedResults{
+		v:      v,
+		logger: logger,
+	}, nil
+}
+
+func (p *ProxySlogNamedResults) A() (r0 int) {
+	p.logger.LogAttrs(context.Background(), slog.LevelInfo, "Calling A")
+	start := time_1.Now()
+	r0_1 := p.v.A()
+	elapsed := time_1.Since(start)
+	p.logger.LogAttrs(context.Background(), slog.LevelInfo, "Called A", slog.Any("r0", r0_1), slog.Duration("elapsed", elapsed))
+	return r0_1
+}
+
+func (p *ProxySlogNamedResults) B(ctx context.Context, id string) (r0 string, r1 error) {
+	p.logger.LogAttrs(ctx, slog.LevelInfo, "Calling B", slog.Any("id", id))
+	start := time_1.Now()
+	r0_1, err := p.v.B(ctx, id)
+	elapsed := time_1.Since(start)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+	p.logger.LogAttrs(ctx, level, "Called B", slog.Any("r0", r0_1), slog.Any("err", err), slog.Duration("elapsed", elapsed))
+	return r0_1, err
+}
+
+func (p *ProxySlogNamedResults) C() (r0 int, _ error) {
+	p.logger.LogAttrs(context.Background(), slog.LevelInfo, "Calling C")
+	start := time_1.Now()
+	r0_1, err := p.v.C()
+	elapsed := time_1.Since(start)
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	}
+	p.logger.LogAttrs(context.Background(), level, "Called C", slog.Any("r0", r0_1), slog.Any("err", err), slog.Duration("elapsed", elapsed))
+	return r0_1, err
+}
+
+// ProxyInterceptedNamedResultsInterceptor intercepts calls of the *ProxyInterceptedNamedResults methods.
+// The method's arguments are passed by args except the leading context.
+// The next func calls the wrapped method and returns its results.
+// The interceptor must return as many results of the same types as next does, otherwise the method panics.
+// Nil is accepted for the results of interface types.
+type ProxyInterceptedNamedResultsInterceptor func(ctx context.Context, method string, args []any, next func() []any) []any
+
+// *ProxyInterceptedNamedResults implements InterceptedNamedResults.
+type ProxyInterceptedNamedResults struct {
+	v           InterceptedNamedResults
+	interceptor ProxyInterceptedNamedResultsInterceptor
+}
+
+func NewProxyInterceptedNamedResults(v InterceptedNamedResults, interceptor ProxyInterceptedNamedResultsInterceptor) (*ProxyInterceptedNamedResults, error) {
+	if v == nil {
+		return nil, errors_1.New("v is nil")
+	}
+	if interceptor == nil {
+		return nil, errors_1.New("interceptor is nil")
+	}
+	return &ProxyInterceptedNamedResults{
+		v:           v,
+		interceptor: interceptor,
+	}, nil
+}
+
+func (p *ProxyInterceptedNamedResults) A() (r0 int) {
+	res := p.interceptor(context.Background(), "A", []any{}, func() []any {
+		r0_1 := p.v.A()
+		return []any{r0_1}
+	})
+
+	if len(res) != 1 {
+		panic(fmt_1.Sprintf("interceptor of method A returns %d results instead of 1", len(res)))
+	}
+
+	r0_1, ok := res[0].(int)
+	if !ok {
+		panic(fmt_1.Sprintf("interceptor of method A returns %T result 0 instead of %s", res[0], "int"))
+	}
+
+	return r0_1
+}
+
+func (p *ProxyInterceptedNamedResults) B(ctx context.Context, id string) (r0 string, r1 error) {
+	res := p.interceptor(ctx, "B", []any{id}, func() []any {
+		r0_1, r1_1 := p.v.B(ctx, id)
+		return []any{r0_1, r1_1}
+	})
+
+	if len(res) != 2 {
+		panic(fmt_1.Sprintf("interceptor of method B returns %d results instead of 2", len(res)))
+	}
+
+	r0_1, ok := res[0].(string)
+	if !ok {
+		panic(fmt_1.Sprintf("interceptor of method B returns %T result 0 instead of %s", res[0], "string"))
+	}
+	r1_1, ok := res[1].(error)
+	if !ok && res[1] != nil {
+		panic(fmt_1.Sprintf("interceptor of method B returns %T result 1 instead of %s", res[1], "error"))
+	}
+
+	return r0_1, r1_1
+}
+
+func (p *ProxyInterceptedNamedResults) C() (r0 int, _ error) {
+	res := p.interceptor(context.Background(), "C", []any{}, func() []any {
+		r0_1, r1 := p.v.C()
+		return []any{r0_1, r1}
+	})
+
+	if len(res) != 2 {
+		panic(fmt_1.Sprintf("interceptor of method C returns %d results instead of 2", len(res)))
+	}
+
+	r0_1, ok := res[0].(int)
+	if !ok {
+		panic(fmt_1.Sprintf("interceptor of method C returns %T result 0 instead of %s", res[0], "int"))
+	}
+	r1, ok := res[1].(error)
+	if !ok && res[1] != nil {
+		panic(fmt_1.Sprintf("interceptor of method C returns %T result 1 instead of %s", res[1], "error"))
+	}
+
+	return r0_1, r1
+}
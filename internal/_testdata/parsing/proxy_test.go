@@ -0,0 +1,254 @@
+package parse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestInterceptedI3(t *testing.T, res []any) *ProxyI3 {
+	t.Helper()
+
+	p, err := NewProxyI3(i3Impl{}, func(context.Context, string, []any, func() []any) []any {
+		return res
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return p
+}
+
+func TestProxyI3_interceptor(t *testing.T) {
+	t.Parallel()
+
+	var gotMethod string
+
+	var gotArgs []any
+
+	p, err := NewProxyI3(i3Impl{}, func(_ context.Context, method string, args []any, next func() []any) []any {
+		gotMethod, gotArgs = method, args
+		return next()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := p.Method1(1, "b")
+	if err != nil || got != (S1{S1Field1: "b", S1Field2: 1}) {
+		t.Fatalf("got %+v, %v, want the impl result", got, err)
+	}
+
+	if gotMethod != "Method1" || len(gotArgs) != 2 || gotArgs[0] != 1 || gotArgs[1] != "b" {
+		t.Fatalf("got the method %q called with %v", gotMethod, gotArgs)
+	}
+}
+
+func TestProxyI3_nilError(t *testing.T) {
+	t.Parallel()
+
+	// Nil is the nil error.
+	if _, err := newTestInterceptedI3(t, []any{S1{}, nil}).Method1(1, "b"); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+}
+
+func TestProxyI3_misbehavingInterceptor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		res  []any
+		want string
+	}{
+		{
+			name: "results number",
+			res:  []any{S1{}},
+			want: "interceptor of method Method1 returns 1 results instead of 2",
+		},
+		{
+			name: "result type",
+			res:  []any{"S1", nil},
+			want: "interceptor of method Method1 returns string result 0 instead of S1",
+		},
+		{
+			name: "nil result",
+			res:  []any{nil, nil},
+			want: "interceptor of method Method1 returns <nil> result 0 instead of S1",
+		},
+		{
+			name: "error type",
+			res:  []any{S1{}, "error"},
+			want: "interceptor of method Method1 returns string result 1 instead of error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				msg, _ := recover().(string)
+				if !strings.Contains(msg, tt.want) {
+					t.Fatalf("got panic %q, want %q", msg, tt.want)
+				}
+			}()
+
+			_, _ = newTestInterceptedI3(t, tt.res).Method1(1, "b")
+		})
+	}
+}
+
+// authImpl returns the session by the credentials.
+type authImpl struct{}
+
+func (authImpl) Login(_ context.Context, user, password string) (Secret, error) {
+	return Secret("session-" + user + "-" + password), nil
+}
+
+func (authImpl) Refresh(_ context.Context, token string) (Secret, error) {
+	return Secret("session-" + token), nil
+}
+
+func TestProxySlogAuth_redact(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	p, err := NewProxySlogAuth(authImpl{}, slog.New(slog.NewTextHandler(&buf, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := p.Login(context.Background(), "alice", "hunter2"); err != nil || got != "session-alice-hunter2" {
+		t.Fatalf("got %q, %v, want the impl result", got, err)
+	}
+
+	if got, err := p.Refresh(context.Background(), "tok123"); err != nil || got != "session-tok123" {
+		t.Fatalf("got %q, %v, want the impl result", got, err)
+	}
+
+	out := buf.String()
+
+	// The password is redacted by -redact, the token by the method's marker and the sessions by the Secret type.
+	for _, secret := range []string{"hunter2", "tok123", "session"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("the secret %q is logged:\n%s", secret, out)
+		}
+	}
+
+	for _, want := range []string{
+		`msg="Calling Login" user=alice password=[REDACTED]`,
+		`msg="Called Login" r0=[REDACTED] err=<nil>`,
+		`msg="Calling Refresh" token=[REDACTED]`,
+		`msg="Called Refresh" r0=[REDACTED] err=<nil>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("the log doesn't contain %q:\n%s", want, out)
+		}
+	}
+}
+
+// slowAuth lasts longer than the threshold for the slow user and fails on the bad password.
+type slowAuth struct{ authImpl }
+
+func (a slowAuth) Login(ctx context.Context, user, password string) (Secret, error) {
+	if user == "slow" {
+		time.Sleep(80 * time.Millisecond)
+	}
+
+	if password == "bad" {
+		return "", errors.New("bad password")
+	}
+
+	return a.authImpl.Login(ctx, user, password)
+}
+
+// decodeLog returns the records logged by the JSON handler.
+func decodeLog(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var record map[string]any
+		if err := dec.Decode(&record); err != nil {
+			t.Fatal(err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestProxySlowAuth_threshold(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	p, err := NewProxySlowAuth(slowAuth{}, slog.New(slog.NewJSONHandler(&buf, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The fast calls are not logged.
+	_, _ = p.Login(context.Background(), "fast", "pw")
+	_, _ = p.Login(context.Background(), "fast", "bad")
+	_, _ = p.Login(context.Background(), "slow", "pw")
+	_, _ = p.Login(context.Background(), "slow", "bad")
+
+	records := decodeLog(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want the slow calls logged only: %v", len(records), records)
+	}
+
+	for i, want := range []struct{ level, err any }{
+		{level: "INFO", err: nil},
+		{level: "ERROR", err: "bad password"},
+	} {
+		record := records[i]
+
+		if record["msg"] != "Called Login" || record["user"] != "slow" || record["level"] != want.level ||
+			record["err"] != want.err {
+			t.Fatalf("record %d: got %v, want the slow call logged at level %v", i, record, want.level)
+		}
+
+		// JSON handler writes durations in nanoseconds.
+		if elapsed, _ := record["elapsed"].(float64); time.Duration(elapsed) < 50*time.Millisecond {
+			t.Fatalf("record %d: got elapsed %v, want at least the threshold", i, record["elapsed"])
+		}
+	}
+}
+
+func TestProxySlogNamedResults_timing(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	p, err := NewProxySlogNamedResults(namedResultsImpl{}, slog.New(slog.NewJSONHandler(&buf, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.A()
+
+	records := decodeLog(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want the call and the result: %v", len(records), records)
+	}
+
+	if _, ok := records[0]["elapsed"]; ok || records[0]["msg"] != "Calling A" {
+		t.Fatalf("got %v, want the call logged without the duration", records[0])
+	}
+
+	if _, ok := records[1]["elapsed"].(float64); !ok || records[1]["msg"] != "Called A" || records[1]["r0"] != 1.0 {
+		t.Fatalf("got %v, want the result logged with the duration", records[1])
+	}
+}
@@ -0,0 +1,209 @@
+// Code generated by "genpls:recover"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/types"
+	io_1 "io"
+	types_2 "parse/types"
+	"runtime/debug"
+)
+
+// PanicError is returned by the recovering wrappers if the wrapped method panics.
+type PanicError struct {
+	Method string
+	Value  any
+	Stack  []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("method %s panics: %v", e.Method, e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// *RecoverI2 implements I2.
+type RecoverI2[T any, U comparable, Q io_1.Reader] struct {
+	v I2[T, U, Q]
+}
+
+func NewRecoverI2[T any, U comparable, Q io_1.Reader](v I2[T, U, Q]) (*RecoverI2[T, U, Q], error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+	return &RecoverI2[T, U, Q]{
+		v: v,
+	}, nil
+}
+
+func (w *RecoverI2[T, U, Q]) IMethod1() {
+	w.v.IMethod1()
+}
+
+func (w *RecoverI2[T, U, Q]) imethod2(t T) (u U) {
+	return w.v.imethod2(t)
+}
+
+func (w *RecoverI2[T, U, Q]) IMethod3(a int, b types_2.S1, c types_2.S2[string], d types_2.S2[*types.Package]) (r0 types_2.S1, r1 error) {
+	defer func() {
+		if v := recover(); v != nil {
+			r1 = &PanicError{Method: "IMethod3", Value: v, Stack: debug.Stack()}
+		}
+	}()
+
+	return w.v.IMethod3(a, b, c, d)
+}
+
+// *RecoverI3 implements I3.
+type RecoverI3 struct {
+	v      I3
+	logger interface{ Log(string, ...any) }
+}
+
+func NewRecoverI3(v I3, logger interface{ Log(string, ...any) }) (*RecoverI3, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+	if logger == nil {
+		return nil, errors.New("logger is nil")
+	}
+	return &RecoverI3{
+		v:      v,
+		logger: logger,
+	}, nil
+}
+
+func (w *RecoverI3) Method1(a int, b string) (r0 S1, r1 error) {
+	defer func() {
+		if v := recover(); v != nil {
+			r1 = &PanicError{Method: "Method1", Value: v, Stack: debug.Stack()}
+		}
+	}()
+
+	return w.v.Method1(a, b)
+}
+
+func (w *RecoverI3) Method2(s *S4[string]) {
+	defer func() {
+		if v := recover(); v != nil {
+			w.logger.Log("Recovered panic in Method2", "value", v, "stack", string(debug.Stack()))
+			panic(v)
+		}
+	}()
+
+	w.v.Method2(s)
+}
+
+// *RecoverCalc implements Calc.
+type RecoverCalc[T Number, S ~[]T, K interface {
+	String() string
+	comparable
+}] struct {
+	v Calc[T, S, K]
+}
+
+func NewRecoverCalc[T Number, S ~[]T, K interface {
+	String() string
+	comparable
+}](v Calc[T, S, K]) (*RecoverCalc[T, S, K], error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+	return &RecoverCalc[T, S, K]{
+		v: v,
+	}, nil
+}
+
+func (w *RecoverCalc[T, S, K]) Sum(values S) (r0 T) {
+	return w.v.Sum(values)
+}
+
+func (w *RecoverCalc[T, S, K]) Lookup(key K) (r0 T, r1 error) {
+	defer func() {
+		if v := recover(); v != nil {
+			r1 = &PanicError{Method: "Lookup", Value: v, Stack: debug.Stack()}
+		}
+	}()
+
+	return w.v.Lookup(key)
+}
+
+// *RecoverClash implements Clash.
+type RecoverClash struct {
+	v Clash
+}
+
+func NewRecoverClash(v Clash) (*RecoverClash, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+	return &RecoverClash{
+		v: v,
+	}, nil
+}
+
+func (w *RecoverClash) A(ctx context.Context, r0 int) (r0_1 int, r1 error) {
+	defer func() {
+		if v := recover(); v != nil {
+			r1 = &PanicError{Method: "A", Value: v, Stack: debug.Stack()}
+		}
+	}()
+
+	return w.v.A(ctx, r0)
+}
+
+func (w *RecoverClash) B(res string, returns int, ok bool, call int) (r0 string, r1 error) {
+	defer func() {
+		if v := recover(); v != nil {
+			r1 = &PanicError{Method: "B", Value: v, Stack: debug.Stack()}
+		}
+	}()
+
+	return w.v.B(res, returns, ok, call)
+}
+
+// *RecoverNamedResults implements NamedResults.
+type RecoverNamedResults struct {
+	v NamedResults
+}
+
+func NewRecoverNamedResults(v NamedResults) (*RecoverNamedResults, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+	return &RecoverNamedResults{
+		v: v,
+	}, nil
+}
+
+func (w *RecoverNamedResults) A() (r0 int) {
+	return w.v.A()
+}
+
+func (w *RecoverNamedResults) B(ctx context.Context, id string) (r0 string, r1 error) {
+	defer func() {
+		if v := recover(); v != nil {
+			r1 = &PanicError{Method: "B", Value: v, Stack: debug.Stack()}
+		}
+	}()
+
+	return w.v.B(ctx, id)
+}
+
+func (w *RecoverNamedResults) C() (r0_1 int, r1 error) {
+	defer func() {
+		if v := recover(); v != nil {
+			r1 = &PanicError{Method: "C", Value: v, Stack: debug.Stack()}
+		}
+	}()
+
+	return w.v.C()
+}
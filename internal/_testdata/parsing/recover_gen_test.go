@@ -0,0 +1,34 @@
+// Code generated by "genpls:recover"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+)
+
+// *RecoverShared implements Shared.
+type RecoverShared struct {
+	v Shared
+}
+
+func NewRecoverShared(v Shared) (*RecoverShared, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+	return &RecoverShared{
+		v: v,
+	}, nil
+}
+
+func (w *RecoverShared) Get(ctx context.Context, id string) (r0 string, r1 error) {
+	defer func() {
+		if v := recover(); v != nil {
+			r1 = &PanicError{Method: "Get", Value: v, Stack: debug.Stack()}
+		}
+	}()
+
+	return w.v.Get(ctx, id)
+}
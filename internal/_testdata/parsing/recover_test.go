@@ -0,0 +1,137 @@
+package parse
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// panickingI3 panics by the value in all methods.
+type panickingI3 struct{ value any }
+
+func (p panickingI3) Method1(int, string) (S1, error) { panic(p.value) }
+
+func (p panickingI3) Method2(*S4[string]) { panic(p.value) }
+
+// panickingNamedResults panics by the value in all methods.
+type panickingNamedResults struct{ value any }
+
+func (p panickingNamedResults) A() int { panic(p.value) }
+
+func (p panickingNamedResults) B(context.Context, string) (string, error) { panic(p.value) }
+
+func (p panickingNamedResults) C() (int, error) { panic(p.value) }
+
+// recordingLogger records the logged messages and arguments.
+type recordingLogger struct {
+	msgs []string
+	args [][]any
+}
+
+func (l *recordingLogger) Log(msg string, args ...any) {
+	l.msgs = append(l.msgs, msg)
+	l.args = append(l.args, args)
+}
+
+func TestRecoverShared_panicError(t *testing.T) {
+	t.Parallel()
+
+	errPanic := errors.New("panic value")
+
+	w, err := NewRecoverShared(sharedFunc(func(context.Context, string) (string, error) {
+		panic(errPanic)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := w.Get(context.Background(), "id")
+	if got != "" {
+		t.Fatalf("got %q, want the zero result", got)
+	}
+
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("got error %v, want *PanicError", err)
+	}
+
+	if panicErr.Method != "Get" || panicErr.Value != errPanic {
+		t.Fatalf("got %+v, want the panic of Get by %v", panicErr, errPanic)
+	}
+
+	if !strings.Contains(string(panicErr.Stack), "TestRecoverShared_panicError") {
+		t.Fatalf("got stack %s, want the stack of the panic", panicErr.Stack)
+	}
+
+	// The error panic value is unwrapped.
+	if !errors.Is(err, errPanic) {
+		t.Fatalf("got error %v, want it wrapping %v", err, errPanic)
+	}
+}
+
+func TestRecoverShared_noPanic(t *testing.T) {
+	t.Parallel()
+
+	w, err := NewRecoverShared(sharedFunc(func(_ context.Context, id string) (string, error) {
+		return id, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := w.Get(context.Background(), "id"); got != "id" || err != nil {
+		t.Fatalf("got %q, %v, want the wrapped result", got, err)
+	}
+}
+
+func TestRecoverI3_log(t *testing.T) {
+	t.Parallel()
+
+	logger := &recordingLogger{}
+
+	w, err := NewRecoverI3(panickingI3{value: "boom"}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The method returning error doesn't log the panic.
+	if _, err := w.Method1(1, "b"); err == nil || err.Error() != "method Method1 panics: boom" {
+		t.Fatalf("got error %v, want the panic error", err)
+	}
+
+	func() {
+		defer func() {
+			if v := recover(); v != "boom" {
+				t.Fatalf("got panic %v, want the re-panic by the value", v)
+			}
+		}()
+
+		w.Method2(nil)
+	}()
+
+	if len(logger.msgs) != 1 || logger.msgs[0] != "Recovered panic in Method2" {
+		t.Fatalf("got logged %q, want the panic of Method2 only", logger.msgs)
+	}
+
+	if args := logger.args[0]; len(args) != 4 || args[0] != "value" || args[1] != "boom" || args[2] != "stack" {
+		t.Fatalf("got logged arguments %v, want the value and the stack", args)
+	}
+}
+
+func TestRecoverNamedResults_propagate(t *testing.T) {
+	t.Parallel()
+
+	w, err := NewRecoverNamedResults(panickingNamedResults{value: "boom"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if v := recover(); v != "boom" {
+			t.Fatalf("got panic %v, want the panic of the method without error result", v)
+		}
+	}()
+
+	w.A()
+}
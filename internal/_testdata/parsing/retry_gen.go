@@ -0,0 +1,372 @@
+// Code generated by "genpls:retry"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"context"
+	"errors"
+	"go/types"
+	io_1 "io"
+	"math/rand/v2"
+	types_2 "parse/types"
+	"time"
+)
+
+// RetryPolicy configures retries of the wrapped methods returning error.
+// Zero fields are set to the defaults of the wrapper.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls of every method including the first one.
+	// If it is positive, it overrides the wrapper's defaults including the per-method ones.
+	// If it is zero, the wrapper's default of the method is used.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, it is doubled for each next retry.
+	Backoff time.Duration
+	// MaxBackoff limits the delay between calls.
+	MaxBackoff time.Duration
+	// Retryable reports whether the call failed with err can be retried.
+	// All errors are retried if Retryable is nil.
+	Retryable func(err error) bool
+}
+
+// delay returns the jittered delay before the retry.
+func (p RetryPolicy) delay(retry int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	d = min(d, p.MaxBackoff)
+	if d <= 0 {
+		return 0
+	}
+
+	return d/2 + rand.N(d/2+1)
+}
+
+// wait blocks for the delay before the retry or until ctx is done.
+func (p RetryPolicy) wait(ctx context.Context, retry int) error {
+	timer := time.NewTimer(p.delay(retry))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// *RetryI2 implements I2.
+type RetryI2[T any, U comparable, Q io_1.Reader] struct {
+	v      I2[T, U, Q]
+	policy RetryPolicy
+}
+
+// NewRetryI2 returns a new *RetryI2 retrying calls of v according to policy.
+func NewRetryI2[T any, U comparable, Q io_1.Reader](v I2[T, U, Q], policy RetryPolicy) (*RetryI2[T, U, Q], error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if policy.Backoff <= 0 {
+		policy.Backoff = 100 * time.Millisecond
+	}
+
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = 10 * time.Second
+	}
+
+	return &RetryI2[T, U, Q]{
+		v:      v,
+		policy: policy,
+	}, nil
+}
+
+func (w *RetryI2[T, U, Q]) IMethod1() {
+	w.v.IMethod1()
+}
+
+func (w *RetryI2[T, U, Q]) IMethod3(a int, b types_2.S1, c types_2.S2[string], d types_2.S2[*types.Package]) (r0 types_2.S1, r1 error) {
+	maxAttempts := w.policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	for attempt := 1; ; attempt++ {
+		r0, r1 = w.v.IMethod3(a, b, c, d)
+		if r1 == nil || attempt >= maxAttempts || !w.policy.retryable(r1) {
+			return r0, r1
+		}
+
+		if waitErr := w.policy.wait(context.Background(), attempt); waitErr != nil {
+			r1 = errors.Join(r1, waitErr)
+			return r0, r1
+		}
+	}
+}
+
+func (w *RetryI2[T, U, Q]) imethod2(t T) (u U) {
+	return w.v.imethod2(t)
+}
+
+// *RetryRepo implements Repo.
+type RetryRepo struct {
+	v      Repo
+	policy RetryPolicy
+}
+
+// NewRetryRepo returns a new *RetryRepo retrying calls of v according to policy.
+func NewRetryRepo(v Repo, policy RetryPolicy) (*RetryRepo, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if policy.Backoff <= 0 {
+		policy.Backoff = 50 * time.Millisecond
+	}
+
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = 10 * time.Second
+	}
+
+	return &RetryRepo{
+		v:      v,
+		policy: policy,
+	}, nil
+}
+
+func (w *RetryRepo) GetItem(ctx context.Context, id string) (r0 Item, r1 error) {
+	maxAttempts := w.policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	for attempt := 1; ; attempt++ {
+		r0, r1 = w.v.GetItem(ctx, id)
+		if r1 == nil || attempt >= maxAttempts || !w.policy.retryable(r1) {
+			return r0, r1
+		}
+
+		if waitErr := w.policy.wait(ctx, attempt); waitErr != nil {
+			r1 = errors.Join(r1, waitErr)
+			return r0, r1
+		}
+	}
+}
+
+func (w *RetryRepo) PutItem(ctx context.Context, item Item) (r0 error) {
+	maxAttempts := w.policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 5
+	}
+
+	for attempt := 1; ; attempt++ {
+		r0 = w.v.PutItem(ctx, item)
+		if r0 == nil || attempt >= maxAttempts || !w.policy.retryable(r0) {
+			return r0
+		}
+
+		if waitErr := w.policy.wait(ctx, attempt); waitErr != nil {
+			r0 = errors.Join(r0, waitErr)
+			return r0
+		}
+	}
+}
+
+func (w *RetryRepo) ListItems(ctx context.Context) (r0 []Item, r1 error) {
+	maxAttempts := w.policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	for attempt := 1; ; attempt++ {
+		r0, r1 = w.v.ListItems(ctx)
+		if r1 == nil || attempt >= maxAttempts || !w.policy.retryable(r1) {
+			return r0, r1
+		}
+
+		if waitErr := w.policy.wait(ctx, attempt); waitErr != nil {
+			r1 = errors.Join(r1, waitErr)
+			return r0, r1
+		}
+	}
+}
+
+func (w *RetryRepo) DeleteItem(ctx context.Context, id string) (r0 error) {
+	maxAttempts := w.policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	for attempt := 1; ; attempt++ {
+		r0 = w.v.DeleteItem(ctx, id)
+		if r0 == nil || attempt >= maxAttempts || !w.policy.retryable(r0) {
+			return r0
+		}
+
+		if waitErr := w.policy.wait(ctx, attempt); waitErr != nil {
+			r0 = errors.Join(r0, waitErr)
+			return r0
+		}
+	}
+}
+
+func (w *RetryRepo) Count(ctx context.Context) (r0 int, r1 error) {
+	maxAttempts := w.policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	for attempt := 1; ; attempt++ {
+		r0, r1 = w.v.Count(ctx)
+		if r1 == nil || attempt >= maxAttempts || !w.policy.retryable(r1) {
+			return r0, r1
+		}
+
+		if waitErr := w.policy.wait(ctx, attempt); waitErr != nil {
+			r1 = errors.Join(r1, waitErr)
+			return r0, r1
+		}
+	}
+}
+
+func (w *RetryRepo) Close() (r0 error) {
+	return w.v.Close()
+}
+
+// *RetryClash implements Clash.
+type RetryClash struct {
+	v      Clash
+	policy RetryPolicy
+}
+
+// NewRetryClash returns a new *RetryClash retrying calls of v according to policy.
+func NewRetryClash(v Clash, policy RetryPolicy) (*RetryClash, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if policy.Backoff <= 0 {
+		policy.Backoff = 100 * time.Millisecond
+	}
+
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = 10 * time.Second
+	}
+
+	return &RetryClash{
+		v:      v,
+		policy: policy,
+	}, nil
+}
+
+func (w *RetryClash) A(ctx context.Context, r0 int) (r0_1 int, r1 error) {
+	maxAttempts := w.policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	for attempt := 1; ; attempt++ {
+		r0_1, r1 = w.v.A(ctx, r0)
+		if r1 == nil || attempt >= maxAttempts || !w.policy.retryable(r1) {
+			return r0_1, r1
+		}
+
+		if waitErr := w.policy.wait(ctx, attempt); waitErr != nil {
+			r1 = errors.Join(r1, waitErr)
+			return r0_1, r1
+		}
+	}
+}
+
+func (w *RetryClash) B(res string, returns int, ok bool, call int) (r0 string, r1 error) {
+	maxAttempts := w.policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	for attempt := 1; ; attempt++ {
+		r0, r1 = w.v.B(res, returns, ok, call)
+		if r1 == nil || attempt >= maxAttempts || !w.policy.retryable(r1) {
+			return r0, r1
+		}
+
+		if waitErr := w.policy.wait(context.Background(), attempt); waitErr != nil {
+			r1 = errors.Join(r1, waitErr)
+			return r0, r1
+		}
+	}
+}
+
+// *RetryNamedResults implements NamedResults.
+type RetryNamedResults struct {
+	v      NamedResults
+	policy RetryPolicy
+}
+
+// NewRetryNamedResults returns a new *RetryNamedResults retrying calls of v according to policy.
+func NewRetryNamedResults(v NamedResults, policy RetryPolicy) (*RetryNamedResults, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if policy.Backoff <= 0 {
+		policy.Backoff = 100 * time.Millisecond
+	}
+
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = 10 * time.Second
+	}
+
+	return &RetryNamedResults{
+		v:      v,
+		policy: policy,
+	}, nil
+}
+
+func (w *RetryNamedResults) A() (r0 int) {
+	return w.v.A()
+}
+
+func (w *RetryNamedResults) B(ctx context.Context, id string) (r0 string, r1 error) {
+	maxAttempts := w.policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	for attempt := 1; ; attempt++ {
+		r0, r1 = w.v.B(ctx, id)
+		if r1 == nil || attempt >= maxAttempts || !w.policy.retryable(r1) {
+			return r0, r1
+		}
+
+		if waitErr := w.policy.wait(ctx, attempt); waitErr != nil {
+			r1 = errors.Join(r1, waitErr)
+			return r0, r1
+		}
+	}
+}
+
+func (w *RetryNamedResults) C() (r0_1 int, r1 error) {
+	maxAttempts := w.policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	for attempt := 1; ; attempt++ {
+		r0_1, r1 = w.v.C()
+		if r1 == nil || attempt >= maxAttempts || !w.policy.retryable(r1) {
+			return r0_1, r1
+		}
+
+		if waitErr := w.policy.wait(context.Background(), attempt); waitErr != nil {
+			r1 = errors.Join(r1, waitErr)
+			return r0_1, r1
+		}
+	}
+}
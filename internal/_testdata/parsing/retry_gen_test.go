@@ -0,0 +1,55 @@
+// Code generated by "genpls:retry"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// *RetryShared implements Shared.
+type RetryShared struct {
+	v      Shared
+	policy RetryPolicy
+}
+
+// NewRetryShared returns a new *RetryShared retrying calls of v according to policy.
+func NewRetryShared(v Shared, policy RetryPolicy) (*RetryShared, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if policy.Backoff <= 0 {
+		policy.Backoff = 100 * time.Millisecond
+	}
+
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = 10 * time.Second
+	}
+
+	return &RetryShared{
+		v:      v,
+		policy: policy,
+	}, nil
+}
+
+func (w *RetryShared) Get(ctx context.Context, id string) (r0 string, r1 error) {
+	maxAttempts := w.policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	for attempt := 1; ; attempt++ {
+		r0, r1 = w.v.Get(ctx, id)
+		if r1 == nil || attempt >= maxAttempts || !w.policy.retryable(r1) {
+			return r0, r1
+		}
+
+		if waitErr := w.policy.wait(ctx, attempt); waitErr != nil {
+			r1 = errors.Join(r1, waitErr)
+			return r0, r1
+		}
+	}
+}
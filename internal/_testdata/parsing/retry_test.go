@@ -0,0 +1,155 @@
+package parse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errRetryCall = errors.New("call failed")
+
+// noBackoff retries the calls immediately.
+var noBackoff = RetryPolicy{Backoff: time.Nanosecond, MaxBackoff: time.Nanosecond}
+
+func newTestRetry(t *testing.T, v Shared, policy RetryPolicy) *RetryShared {
+	t.Helper()
+
+	w, err := NewRetryShared(v, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return w
+}
+
+// countingShared fails the calls until the call number reaches succeedAt, succeedAt zero fails all calls.
+func countingShared(calls *int, succeedAt int) sharedFunc {
+	return func(_ context.Context, id string) (string, error) {
+		*calls++
+		if *calls != succeedAt {
+			return "", errRetryCall
+		}
+
+		return id, nil
+	}
+}
+
+func TestRetryShared_attempts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		maxAttempts int
+		succeedAt   int
+		wantCalls   int
+		wantErr     error
+	}{
+		{name: "default attempts", succeedAt: 0, wantCalls: 3, wantErr: errRetryCall},
+		{name: "succeeds on retry", succeedAt: 2, wantCalls: 2, wantErr: nil},
+		{name: "policy attempts", maxAttempts: 5, succeedAt: 0, wantCalls: 5, wantErr: errRetryCall},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			policy := noBackoff
+			policy.MaxAttempts = tt.maxAttempts
+
+			var calls int
+
+			_, err := newTestRetry(t, countingShared(&calls, tt.succeedAt), policy).Get(context.Background(), "id")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if calls != tt.wantCalls {
+				t.Fatalf("got %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// putRepo counts the PutItem calls failing all of them.
+type putRepo struct {
+	UnimplementedRepo
+	calls int
+}
+
+func (r *putRepo) PutItem(context.Context, Item) error {
+	r.calls++
+	return errRetryCall
+}
+
+func TestRetryRepo_methodAttempts(t *testing.T) {
+	t.Parallel()
+
+	// The directive sets -methods=PutItem:5, positive MaxAttempts overrides it.
+	for maxAttempts, wantCalls := range map[int]int{0: 5, 2: 2} {
+		policy := noBackoff
+		policy.MaxAttempts = maxAttempts
+
+		repo := &putRepo{}
+
+		w, err := NewRetryRepo(repo, policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := w.PutItem(context.Background(), Item{}); !errors.Is(err, errRetryCall) {
+			t.Fatalf("max attempts %d: got error %v, want %v", maxAttempts, err, errRetryCall)
+		}
+
+		if repo.calls != wantCalls {
+			t.Fatalf("max attempts %d: got %d calls, want %d", maxAttempts, repo.calls, wantCalls)
+		}
+	}
+}
+
+func TestRetryShared_notRetryable(t *testing.T) {
+	t.Parallel()
+
+	policy := noBackoff
+	policy.Retryable = func(err error) bool { return !errors.Is(err, errRetryCall) }
+
+	var calls int
+
+	w := newTestRetry(t, countingShared(&calls, 0), policy)
+
+	if _, err := w.Get(context.Background(), "id"); !errors.Is(err, errRetryCall) {
+		t.Fatalf("got error %v, want %v", err, errRetryCall)
+	}
+
+	if calls != 1 {
+		t.Fatalf("got %d calls, want the only call", calls)
+	}
+}
+
+func TestRetryShared_cancelBackoff(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	called := make(chan struct{}, 1)
+
+	w := newTestRetry(t, sharedFunc(func(context.Context, string) (string, error) {
+		called <- struct{}{}
+		return "", errRetryCall
+	}), RetryPolicy{Backoff: time.Hour, MaxBackoff: time.Hour})
+
+	go func() {
+		<-called
+		cancel()
+	}()
+
+	_, err := w.Get(ctx, "id")
+	if !errors.Is(err, errRetryCall) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want the call error joined with %v", err, context.Canceled)
+	}
+
+	if len(called) != 0 {
+		t.Fatal("the call must not be retried after the cancellation")
+	}
+}
@@ -0,0 +1,27 @@
+package parse
+
+import "context"
+
+// Shared is declared by the test file, the generated test files don't redeclare
+// the declarations shared with the generated files of the package.
+//
+//genpls:stub -mode=error
+//genpls:recover
+//genpls:retry
+//genpls:breaker
+//genpls:metrics -expvar
+//genpls:trace
+//genpls:cache -methods=Get
+type Shared interface {
+	Get(ctx context.Context, id string) (string, error)
+}
+
+// ShortCached caches few results of Shared for a short time.
+//
+//genpls:cache -methods=Get -ttl=50ms -size=2
+type ShortCached = Shared
+
+// sharedFunc implements Shared by the func.
+type sharedFunc func(ctx context.Context, id string) (string, error)
+
+func (f sharedFunc) Get(ctx context.Context, id string) (string, error) { return f(ctx, id) }
@@ -4,11 +4,17 @@
 package parse
 
 import (
+	"context"
+	errors_1 "errors"
+	fmt_1 "fmt"
 	"go/types"
 	io_1 "io"
 	types_2 "parse/types"
 )
 
+// ErrNotImplemented is returned by the stub methods which are not implemented.
+var ErrNotImplemented = errors_1.New("not implemented")
+
 // *UnimplementedI2 implements I2.
 type UnimplementedI2[T any, U comparable, Q io_1.Reader] struct{}
 
@@ -16,11 +22,136 @@ func (*UnimplementedI2[T, U, Q]) IMethod1() {
 	panic("method IMethod1 is not implemented!")
 }
 
+func (*UnimplementedI2[T, U, Q]) imethod2(t T) (u U) {
+	panic("method imethod2 is not implemented!")
+}
+
 func (*UnimplementedI2[T, U, Q]) IMethod3(a int, b types_2.S1, c types_2.S2[string], d types_2.S2[*types.Package]) (types_2.S1, error) {
 	panic("method IMethod3 is not implemented!")
 }
 
-func (*UnimplementedI2[T, U, Q]) imethod2(t T) (u U) {
-	panic("method imethod2 is not implemented!")
+// *UnimplementedI3 implements I3.
+type UnimplementedI3 struct{}
+
+func (*UnimplementedI3) Method1(a int, b string) (r0 S1, r1 error) {
+	return
+}
+
+func (*UnimplementedI3) Method2(s *S4[string]) {
+}
+
+// *UnimplementedRepo implements Repo.
+type UnimplementedRepo struct{}
+
+func (*UnimplementedRepo) GetItem(ctx context.Context, id string) (r0 Item, r1 error) {
+	return r0, fmt_1.Errorf("method GetItem: %w", ErrNotImplemented)
+}
+
+func (*UnimplementedRepo) PutItem(ctx context.Context, item Item) (r0 error) {
+	return fmt_1.Errorf("method PutItem: %w", ErrNotImplemented)
 }
 
+func (*UnimplementedRepo) ListItems(ctx context.Context) (r0 []Item, r1 error) {
+	return r0, fmt_1.Errorf("method ListItems: %w", ErrNotImplemented)
+}
+
+func (*UnimplementedRepo) DeleteItem(ctx context.Context, id string) (r0 error) {
+	return fmt_1.Errorf("method DeleteItem: %w", ErrNotImplemented)
+}
+
+func (*UnimplementedRepo) Count(ctx context.Context) (r0 int, r1 error) {
+	return r0, fmt_1.Errorf("method Count: %w", ErrNotImplemented)
+}
+
+func (*UnimplementedRepo) Close() (r0 error) {
+	return fmt_1.Errorf("method Close: %w", ErrNotImplemented)
+}
+
+// *UnimplementedShadowed implements Shadowed.
+type UnimplementedShadowed struct{}
+
+func (*UnimplementedShadowed) Parse(ctx context.Context, fmt string, time *types.Package) (errors []error, err error) {
+	return errors, fmt_1.Errorf("method Parse: %w", ErrNotImplemented)
+}
+
+// *UnimplementedEmbedded implements Embedded.
+type UnimplementedEmbedded struct{}
+
+// Get returns the value stored by the key.
+func (*UnimplementedEmbedded) Get(key string) (S1, error) {
+	panic("method Get is not implemented!")
+}
+
+func (*UnimplementedEmbedded) Read(p []byte) (n int, err error) {
+	panic("method Read is not implemented!")
+}
+
+func (*UnimplementedEmbedded) Close() error {
+	panic("method Close is not implemented!")
+}
+
+// Name returns the name.
+func (*UnimplementedEmbedded) Name() string {
+	panic("method Name is not implemented!")
+}
+
+// *UnimplementedKeyed implements Keyed.
+type UnimplementedKeyed[V any] struct{}
+
+// Get returns the value stored by the key.
+func (*UnimplementedKeyed[V]) Get(key string) (r0 []V, r1 error) {
+	return
+}
+
+// Keys returns the stored keys.
+func (*UnimplementedKeyed[V]) Keys() (r0 []string) {
+	return
+}
+
+// *UnimplementedS1Getter implements S1Getter.
+type UnimplementedS1Getter struct{}
+
+// Get returns the value stored by the key.
+func (*UnimplementedS1Getter) Get(key int) (r0 S1, r1 error) {
+	return r0, fmt_1.Errorf("method Get: %w", ErrNotImplemented)
+}
+
+// *UnimplementedCalc implements Calc.
+type UnimplementedCalc[T Number, S ~[]T, K interface {
+	String() string
+	comparable
+}] struct{}
+
+func (*UnimplementedCalc[T, S, K]) Sum(values S) T {
+	panic("method Sum is not implemented!")
+}
+
+func (*UnimplementedCalc[T, S, K]) Lookup(key K) (T, error) {
+	panic("method Lookup is not implemented!")
+}
+
+// *UnimplementedClash implements Clash.
+type UnimplementedClash struct{}
+
+func (*UnimplementedClash) A(ctx context.Context, r0 int) (r0_1 int, r1 error) {
+	return
+}
+
+func (*UnimplementedClash) B(res string, returns int, ok bool, call int) (r0 string, r1 error) {
+	return
+}
+
+// *UnimplementedNamedResults implements NamedResults.
+type UnimplementedNamedResults struct{}
+
+func (*UnimplementedNamedResults) A() (r0 int) {
+	return
+}
+
+func (*UnimplementedNamedResults) B(ctx context.Context, id string) (r0 string, r1 error) {
+	return
+}
+
+func (*UnimplementedNamedResults) C() (r0 int, r1 error) {
+	return
+}
@@ -0,0 +1,16 @@
+// Code generated by "genpls:stub"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"context"
+	"fmt"
+)
+
+// *UnimplementedShared implements Shared.
+type UnimplementedShared struct{}
+
+func (*UnimplementedShared) Get(ctx context.Context, id string) (r0 string, r1 error) {
+	return r0, fmt.Errorf("method Get: %w", ErrNotImplemented)
+}
@@ -0,0 +1,230 @@
+// Code generated by "genpls:trace"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"context"
+	"errors"
+)
+
+// Tracer starts spans of the calls of the methods wrapped by the trace wrappers.
+type Tracer interface {
+	// Start starts a span named name and returns the context carrying the span.
+	Start(ctx context.Context, name string) (context.Context, TraceSpan)
+}
+
+// TraceSpan is a span started by Tracer.
+type TraceSpan interface {
+	// RecordError records the error returned by the call.
+	RecordError(err error)
+	// End ends the span.
+	End()
+}
+
+// *TraceI3 implements I3.
+type TraceI3 struct {
+	v      I3
+	tracer Tracer
+}
+
+// NewTraceI3 returns a new *TraceI3 tracing calls of v with tracer.
+func NewTraceI3(v I3, tracer Tracer) (*TraceI3, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if tracer == nil {
+		return nil, errors.New("tracer is nil")
+	}
+
+	return &TraceI3{
+		v:      v,
+		tracer: tracer,
+	}, nil
+}
+
+func (w *TraceI3) Method1(a int, b string) (r0 S1, r1 error) {
+	_, span := w.tracer.Start(context.Background(), "I3.Method1")
+	defer span.End()
+
+	r0, r1 = w.v.Method1(a, b)
+	if r1 != nil {
+		span.RecordError(r1)
+	}
+
+	return r0, r1
+}
+
+func (w *TraceI3) Method2(s *S4[string]) {
+	_, span := w.tracer.Start(context.Background(), "I3.Method2")
+	defer span.End()
+
+	w.v.Method2(s)
+}
+
+// *TraceRepo implements Repo.
+type TraceRepo struct {
+	v      Repo
+	tracer Tracer
+}
+
+// NewTraceRepo returns a new *TraceRepo tracing calls of v with tracer.
+func NewTraceRepo(v Repo, tracer Tracer) (*TraceRepo, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if tracer == nil {
+		return nil, errors.New("tracer is nil")
+	}
+
+	return &TraceRepo{
+		v:      v,
+		tracer: tracer,
+	}, nil
+}
+
+func (w *TraceRepo) GetItem(ctx context.Context, id string) (r0 Item, r1 error) {
+	ctx, span := w.tracer.Start(ctx, "Repo.GetItem")
+	defer span.End()
+
+	r0, r1 = w.v.GetItem(ctx, id)
+	if r1 != nil {
+		span.RecordError(r1)
+	}
+
+	return r0, r1
+}
+
+func (w *TraceRepo) PutItem(ctx context.Context, item Item) (r0 error) {
+	ctx, span := w.tracer.Start(ctx, "Repo.PutItem")
+	defer span.End()
+
+	r0 = w.v.PutItem(ctx, item)
+	if r0 != nil {
+		span.RecordError(r0)
+	}
+
+	return r0
+}
+
+func (w *TraceRepo) ListItems(ctx context.Context) (r0 []Item, r1 error) {
+	ctx, span := w.tracer.Start(ctx, "Repo.ListItems")
+	defer span.End()
+
+	r0, r1 = w.v.ListItems(ctx)
+	if r1 != nil {
+		span.RecordError(r1)
+	}
+
+	return r0, r1
+}
+
+func (w *TraceRepo) DeleteItem(ctx context.Context, id string) (r0 error) {
+	ctx, span := w.tracer.Start(ctx, "Repo.DeleteItem")
+	defer span.End()
+
+	r0 = w.v.DeleteItem(ctx, id)
+	if r0 != nil {
+		span.RecordError(r0)
+	}
+
+	return r0
+}
+
+func (w *TraceRepo) Count(ctx context.Context) (r0 int, r1 error) {
+	ctx, span := w.tracer.Start(ctx, "Repo.Count")
+	defer span.End()
+
+	r0, r1 = w.v.Count(ctx)
+	if r1 != nil {
+		span.RecordError(r1)
+	}
+
+	return r0, r1
+}
+
+func (w *TraceRepo) Close() (r0 error) {
+	return w.v.Close()
+}
+
+// *TraceClash implements Clash.
+type TraceClash struct {
+	v      Clash
+	tracer Tracer
+}
+
+// NewTraceClash returns a new *TraceClash tracing calls of v with tracer.
+func NewTraceClash(v Clash, tracer Tracer) (*TraceClash, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if tracer == nil {
+		return nil, errors.New("tracer is nil")
+	}
+
+	return &TraceClash{
+		v:      v,
+		tracer: tracer,
+	}, nil
+}
+
+func (w *TraceClash) A(ctx context.Context, r0 int) (r0_1 int, r1 error) {
+	ctx, span := w.tracer.Start(ctx, "Clash.A")
+	defer span.End()
+
+	r0_1, r1 = w.v.A(ctx, r0)
+	if r1 != nil {
+		span.RecordError(r1)
+	}
+
+	return r0_1, r1
+}
+
+func (w *TraceClash) B(res string, returns int, ok bool, call int) (r0 string, r1 error) {
+	return w.v.B(res, returns, ok, call)
+}
+
+// *TraceNamedResults implements NamedResults.
+type TraceNamedResults struct {
+	v      NamedResults
+	tracer Tracer
+}
+
+// NewTraceNamedResults returns a new *TraceNamedResults tracing calls of v with tracer.
+func NewTraceNamedResults(v NamedResults, tracer Tracer) (*TraceNamedResults, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if tracer == nil {
+		return nil, errors.New("tracer is nil")
+	}
+
+	return &TraceNamedResults{
+		v:      v,
+		tracer: tracer,
+	}, nil
+}
+
+func (w *TraceNamedResults) A() (r0 int) {
+	return w.v.A()
+}
+
+func (w *TraceNamedResults) B(ctx context.Context, id string) (r0 string, r1 error) {
+	ctx, span := w.tracer.Start(ctx, "NamedResults.B")
+	defer span.End()
+
+	r0, r1 = w.v.B(ctx, id)
+	if r1 != nil {
+		span.RecordError(r1)
+	}
+
+	return r0, r1
+}
+
+func (w *TraceNamedResults) C() (r0_1 int, r1 error) {
+	return w.v.C()
+}
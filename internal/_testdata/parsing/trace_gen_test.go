@@ -0,0 +1,43 @@
+// Code generated by "genpls:trace"; DO NOT EDIT.
+// github.com/WinPooh32/genpls
+
+package parse
+
+import (
+	"context"
+	"errors"
+)
+
+// *TraceShared implements Shared.
+type TraceShared struct {
+	v      Shared
+	tracer Tracer
+}
+
+// NewTraceShared returns a new *TraceShared tracing calls of v with tracer.
+func NewTraceShared(v Shared, tracer Tracer) (*TraceShared, error) {
+	if v == nil {
+		return nil, errors.New("v is nil")
+	}
+
+	if tracer == nil {
+		return nil, errors.New("tracer is nil")
+	}
+
+	return &TraceShared{
+		v:      v,
+		tracer: tracer,
+	}, nil
+}
+
+func (w *TraceShared) Get(ctx context.Context, id string) (r0 string, r1 error) {
+	ctx, span := w.tracer.Start(ctx, "Shared.Get")
+	defer span.End()
+
+	r0, r1 = w.v.Get(ctx, id)
+	if r1 != nil {
+		span.RecordError(r1)
+	}
+
+	return r0, r1
+}
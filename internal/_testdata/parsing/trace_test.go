@@ -0,0 +1,151 @@
+package parse
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type spanKey struct{}
+
+// recordingSpan records the errors and the End calls.
+type recordingSpan struct {
+	name  string
+	errs  []error
+	ended int
+}
+
+func (s *recordingSpan) RecordError(err error) { s.errs = append(s.errs, err) }
+
+func (s *recordingSpan) End() { s.ended++ }
+
+// recordingTracer records the started spans, the returned context carries the span by spanKey.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+func (tr *recordingTracer) Start(ctx context.Context, name string) (context.Context, TraceSpan) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	span := &recordingSpan{name: name}
+	tr.spans = append(tr.spans, span)
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// traced returns the traced calls as the spans names, the errors and the End calls.
+func (tr *recordingTracer) traced() []recordingSpan {
+	spans := make([]recordingSpan, len(tr.spans))
+	for i, span := range tr.spans {
+		spans[i] = *span
+	}
+
+	return spans
+}
+
+var errTraceCall = errors.New("call failed")
+
+func TestTraceShared_span(t *testing.T) {
+	t.Parallel()
+
+	tracer := &recordingTracer{}
+
+	w, err := NewTraceShared(sharedFunc(func(ctx context.Context, id string) (string, error) {
+		// The wrapped method is called by the context carrying the span.
+		if _, ok := ctx.Value(spanKey{}).(*recordingSpan); !ok {
+			return "", errors.New("the context doesn't carry the span")
+		}
+
+		if id == "fail" {
+			return "", errTraceCall
+		}
+
+		return id, nil
+	}), tracer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := w.Get(context.Background(), "id"); got != "id" || err != nil {
+		t.Fatalf("got %q, %v, want the wrapped result", got, err)
+	}
+
+	if _, err := w.Get(context.Background(), "fail"); !errors.Is(err, errTraceCall) {
+		t.Fatalf("got error %v, want %v", err, errTraceCall)
+	}
+
+	want := []recordingSpan{
+		{name: "Shared.Get", ended: 1},
+		{name: "Shared.Get", errs: []error{errTraceCall}, ended: 1},
+	}
+
+	if got := tracer.traced(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got spans %+v, want %+v", got, want)
+	}
+}
+
+// failingI3 fails Method1.
+type failingI3 struct{ i3Impl }
+
+func (failingI3) Method1(int, string) (S1, error) { return S1{}, errTraceCall }
+
+func TestTraceI3_background(t *testing.T) {
+	t.Parallel()
+
+	tracer := &recordingTracer{}
+
+	// The directive traces the methods without context by -background.
+	w, err := NewTraceI3(failingI3{}, tracer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = w.Method1(1, "b")
+	w.Method2(nil)
+
+	want := []recordingSpan{
+		{name: "I3.Method1", errs: []error{errTraceCall}, ended: 1},
+		{name: "I3.Method2", ended: 1},
+	}
+
+	if got := tracer.traced(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got spans %+v, want %+v", got, want)
+	}
+}
+
+// namedResultsImpl fails all methods returning error.
+type namedResultsImpl struct{}
+
+func (namedResultsImpl) A() int { return 1 }
+
+func (namedResultsImpl) B(context.Context, string) (string, error) { return "", errTraceCall }
+
+func (namedResultsImpl) C() (int, error) { return 0, errTraceCall }
+
+func TestTraceNamedResults_noBackground(t *testing.T) {
+	t.Parallel()
+
+	tracer := &recordingTracer{}
+
+	w, err := NewTraceNamedResults(namedResultsImpl{}, tracer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w.A()
+	_, _ = w.B(context.Background(), "id")
+	_, _ = w.C()
+
+	// The methods without context are not traced without -background.
+	want := []recordingSpan{
+		{name: "NamedResults.B", errs: []error{errTraceCall}, ended: 1},
+	}
+
+	if got := tracer.traced(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got spans %+v, want %+v", got, want)
+	}
+}
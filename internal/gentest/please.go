@@ -0,0 +1,59 @@
+// Package gentest helps testing the generators on the sources parsed in memory.
+package gentest
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"testing"
+
+	"github.com/WinPooh32/genpls/gen"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+// Please returns the command with the arguments for the type spec declared at the source of the package p.
+// The source file is /p/p.go.
+func Please(t *testing.T, src, name string, args ...string) gen.Please {
+	t.Helper()
+
+	filename := filepath.FromSlash("/p/p.go")
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	require.NoError(t, err)
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+
+	pkg, err := conf.Check("p", fset, []*ast.File{file}, nil)
+	require.NoError(t, err)
+
+	var spec *ast.TypeSpec
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		if ts, ok := node.(*ast.TypeSpec); ok && ts.Name.Name == name {
+			spec = ts
+		}
+
+		return spec == nil
+	})
+	require.NotNil(t, spec)
+
+	return gen.Please{
+		Filename: filename,
+		Args:     args,
+		TS: &gen.TypeSpec{
+			Pkg: &packages.Package{
+				Name:   "p",
+				Fset:   fset,
+				Syntax: []*ast.File{file},
+				Types:  pkg,
+			},
+			Spec: spec,
+		},
+	}
+}